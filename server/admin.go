@@ -0,0 +1,60 @@
+package socialmedia
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Admin is an administrator account. Admins are kept apart from users and
+// sign in with their own credentials and tokens.
+type Admin struct {
+	ID        uint      `json:"id"`
+	Username  string    `json:"username"`
+	Password  string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (a *Admin) Validate() error {
+	if a.Username == "" {
+		return Errorf(EINVALID, "username is required.")
+	}
+
+	if a.Password == "" {
+		return Errorf(EINVALID, "password is required.")
+	}
+
+	return nil
+}
+
+func (a *Admin) SetPassword(password string) error {
+	hashBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	a.Password = string(hashBytes)
+
+	return nil
+}
+
+func (a *Admin) VerifyPassword(password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(a.Password), []byte(password))
+}
+
+type AdminService interface {
+	FindAdminByID(ctx context.Context, id uint) (*Admin, error)
+	FindAdmins(ctx context.Context, filter AdminFilter) ([]*Admin, int, error)
+	Authenticate(ctx context.Context, username, password string) (*Admin, error)
+	CreateAdmin(ctx context.Context, admin *Admin) error
+}
+
+type AdminFilter struct {
+	ID       *uint   `json:"id"`
+	Username *string `json:"username"`
+
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
@@ -0,0 +1,29 @@
+package socialmedia
+
+import (
+	"context"
+	"time"
+)
+
+type AdminToken struct {
+	ID          uint      `json:"id"`
+	AdminID     uint      `json:"admin_id"`
+	AccessToken string    `json:"access_token"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type AdminTokenService interface {
+	FindAdminTokens(ctx context.Context, filter AdminTokenFilter) ([]*AdminToken, int, error)
+	CreateAdminToken(ctx context.Context, token *AdminToken) error
+	DeleteAdminToken(ctx context.Context, id uint) error
+}
+
+type AdminTokenFilter struct {
+	ID          *uint   `json:"id"`
+	AdminID     *uint   `json:"admin_id"`
+	AccessToken *string `json:"access_token"`
+
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"log"
 
+	sm "github.com/maliByatzes/socialmedia"
 	"github.com/maliByatzes/socialmedia/config"
 	"github.com/maliByatzes/socialmedia/http"
 	"github.com/maliByatzes/socialmedia/postgres"
@@ -15,15 +17,46 @@ func main() {
 	}
 
 	db := postgres.NewDB(cfg.DBURL)
+	db.CryptoKey = cfg.CryptoKey
 	if err := db.Open(); err != nil {
 		log.Fatalf("cannot open database: %v", err)
 	}
 	defer db.Close()
 
-	srv, err := http.NewServer(db, cfg.SecretKey)
+	srv, err := http.NewServer(db, cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer srv.Close()
+
+	if cfg.AdminUsername != "" && cfg.AdminPassword != "" {
+		if err := createFirstAdmin(srv.AdminService, cfg.AdminUsername, cfg.AdminPassword); err != nil {
+			log.Fatalf("cannot create first admin: %v", err)
+		}
+	}
+
 	log.Fatal(srv.Run(cfg.Port))
 }
+
+// createFirstAdmin creates the given admin when there are none yet.
+func createFirstAdmin(adminService sm.AdminService, username, password string) error {
+	ctx := context.Background()
+
+	if _, n, err := adminService.FindAdmins(ctx, sm.AdminFilter{Limit: 1}); err != nil {
+		return err
+	} else if n > 0 {
+		return nil
+	}
+
+	admin := sm.Admin{Username: username}
+	if err := admin.SetPassword(password); err != nil {
+		return err
+	}
+
+	if err := adminService.CreateAdmin(ctx, &admin); err != nil {
+		return err
+	}
+
+	log.Printf("Created first admin %q", admin.Username)
+	return nil
+}
@@ -0,0 +1,53 @@
+package socialmedia
+
+import (
+	"context"
+	"time"
+)
+
+type Comment struct {
+	ID         uint      `json:"id"`
+	Body       string    `json:"body"`
+	UserID     uint      `json:"user_id"`
+	PostID     uint      `json:"post_id"`
+	ParentID   *uint     `json:"parent_id"`
+	ReplyCount int       `json:"reply_count"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (c *Comment) Validate() error {
+	if c.Body == "" {
+		return Errorf(EINVALID, "Body is required.")
+	}
+
+	if c.PostID == 0 {
+		return Errorf(EINVALID, "PostID is required.")
+	}
+
+	return nil
+}
+
+type CommentService interface {
+	FindCommentByID(ctx context.Context, id uint) (*Comment, error)
+	FindComments(ctx context.Context, filter CommentFilter) ([]*Comment, int, error)
+	CreateComment(ctx context.Context, comment *Comment) error
+	UpdateComment(ctx context.Context, id uint, upd CommentUpdate) (*Comment, error)
+	DeleteComment(ctx context.Context, id uint) error
+}
+
+type CommentFilter struct {
+	ID     *uint `json:"id"`
+	PostID *uint `json:"post_id"`
+	UserID *uint `json:"user_id"`
+
+	// ParentID of 0 restricts to top-level comments.
+	ParentID *uint `json:"parent_id"`
+
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+type CommentUpdate struct {
+	Body *string `json:"body"`
+}
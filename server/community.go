@@ -12,6 +12,12 @@ type Community struct {
 	Banner      string    `json:"banner"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	// RequiresPostApproval sends new posts from non-moderators to the
+	// community's pending queue.
+	RequiresPostApproval bool `json:"requires_post_approval"`
+
+	Rules []*Rule `json:"rules,omitempty"`
 }
 
 func (c *Community) Validate() error {
@@ -31,15 +37,17 @@ type CommunityService interface {
 }
 
 type CommunityFilter struct {
-	ID   *uint   `json:"id"`
-	Name *string `json:"name"`
+	ID          *uint   `json:"id"`
+	Name        *string `json:"name"`
+	ModeratorID *uint   `json:"moderator_id"`
 
 	Limit  int `json:"limit"`
 	Offset int `json:"offset"`
 }
 
 type CommunityUpdate struct {
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
-	Banner      *string `json:"banner"`
+	Name                 *string `json:"name"`
+	Description          *string `json:"description"`
+	Banner               *string `json:"banner"`
+	RequiresPostApproval *bool   `json:"requires_post_approval"`
 }
@@ -14,10 +14,13 @@ type CBU struct {
 type CBUService interface {
 	FindCBUs(ctx context.Context, filter CBUFilter) ([]*CBU, int, error)
 	CreateCBU(ctx context.Context, cbu *CBU) error
-	DeleteCBU(ctx context.Context, id uint) error
+	DeleteCBU(ctx context.Context, communityID, userID uint) error
 }
 
 type CBUFilter struct {
 	CommunityID *uint `json:"community_id"`
 	UserID      *uint `json:"user_id"`
+
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
 }
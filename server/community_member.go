@@ -16,8 +16,8 @@ type CommunityMember struct {
 type CommunityMemberService interface {
 	FindCommunityMembers(ctx context.Context, filter CommunityMemberFilter) ([]*CommunityMember, int, error)
 	CreateCommunityMember(ctx context.Context, cm *CommunityMember) error
-	UpdateCommunityMember(ctx context.Context, id uint, upd CommunityMemberUpdate) (*CommunityMember, error)
-	DeleteCommunityMember(ctx context.Context, id uint) error
+	UpdateCommunityMember(ctx context.Context, communityID, userID uint, upd CommunityMemberUpdate) (*CommunityMember, error)
+	DeleteCommunityMember(ctx context.Context, communityID, userID uint) error
 }
 
 type CommunityMemberFilter struct {
@@ -0,0 +1,53 @@
+package socialmedia
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Config holds the runtime settings stored in the configs table.
+type Config struct {
+	ID uint `json:"id"`
+
+	// UsePerspectiveAPI turns on the remote content moderation provider at
+	// CategoryFilteringServiceProvider.
+	UsePerspectiveAPI                bool   `json:"use_perspective_api"`
+	CategoryFilteringServiceProvider string `json:"category_filtering_service_provider"`
+
+	// CategoryFilteringRequestTimeout is in milliseconds.
+	CategoryFilteringRequestTimeout int `json:"category_filtering_request_timeout"`
+}
+
+// RequestTimeout returns the timeout for requests to the content moderation
+// provider. Zero leaves it to the moderator's default.
+func (c *Config) RequestTimeout() time.Duration {
+	return time.Duration(c.CategoryFilteringRequestTimeout) * time.Millisecond
+}
+
+func (c *Config) Validate() error {
+	if c.CategoryFilteringRequestTimeout < 0 {
+		return Errorf(EINVALID, "category_filtering_request_timeout cannot be negative.")
+	}
+
+	if c.UsePerspectiveAPI {
+		if u, err := url.Parse(c.CategoryFilteringServiceProvider); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return Errorf(EINVALID, "category_filtering_service_provider must be an http(s) URL.")
+		}
+	}
+
+	return nil
+}
+
+// ConfigService manages the single row of the configs table. Changes are
+// read by the server on the next request, without a restart.
+type ConfigService interface {
+	FindConfig(ctx context.Context) (*Config, error)
+	UpdateConfig(ctx context.Context, upd ConfigUpdate) (*Config, error)
+}
+
+type ConfigUpdate struct {
+	UsePerspectiveAPI                *bool   `json:"use_perspective_api"`
+	CategoryFilteringServiceProvider *string `json:"category_filtering_service_provider"`
+	CategoryFilteringRequestTimeout  *int    `json:"category_filtering_request_timeout"`
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"errors"
 	"os"
+	"strconv"
+	"strings"
 
 	_ "github.com/joho/godotenv/autoload"
 )
@@ -13,8 +15,33 @@ type Config struct {
 	Port      string
 	SecretKey string
 
+	// AdminSecretKey signs admin tokens, which are kept apart from user
+	// tokens. AdminUsername and AdminPassword, when set, create the first
+	// admin on startup.
+	AdminSecretKey string
+	AdminUsername  string
+	AdminPassword  string
+
+	// TokenType picks the token maker, "jwt" (the default) or "paseto".
+	// With "paseto", JWTFallback keeps accepting JWTs issued before the
+	// switch; turn it off once they have all expired.
+	TokenType   string
+	JWTFallback bool
+
 	Email         string
 	EmailPassword string
+
+	// CryptoKey encrypts stored device data with AES, so it must be 16, 24
+	// or 32 bytes long.
+	CryptoKey string
+
+	// ModerationKeywords are checked by the local content moderator. Entries
+	// starting with "re:" are regular expressions.
+	ModerationKeywords []string
+
+	// LogRetentionDays is how long logs are kept, 90 days by default. Zero
+	// keeps them forever.
+	LogRetentionDays int
 }
 
 func NewConfig() (Config, error) {
@@ -38,6 +65,28 @@ func NewConfig() (Config, error) {
 		return Config{}, errors.New("error: SECRET_KEY is not set!")
 	}
 
+	adminSecretKey, ok := os.LookupEnv("ADMIN_SECRET_KEY")
+	if !ok {
+		return Config{}, errors.New("error: ADMIN_SECRET_KEY is not set!")
+	}
+
+	tokenType := "jwt"
+	if v, ok := os.LookupEnv("TOKEN_TYPE"); ok && v != "" {
+		tokenType = strings.ToLower(v)
+	}
+	if tokenType != "jwt" && tokenType != "paseto" {
+		return Config{}, errors.New("error: TOKEN_TYPE must be jwt or paseto!")
+	}
+
+	jwtFallback := true
+	if v, ok := os.LookupEnv("TOKEN_JWT_FALLBACK"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return Config{}, errors.New("error: TOKEN_JWT_FALLBACK must be a boolean!")
+		}
+		jwtFallback = b
+	}
+
 	email, ok := os.LookupEnv("EMAIL")
 	if !ok {
 		return Config{}, errors.New("error: EMAIL is not set!")
@@ -48,12 +97,48 @@ func NewConfig() (Config, error) {
 		return Config{}, errors.New("error: EMAIL_PASSWORD is not set!")
 	}
 
+	cryptoKey, ok := os.LookupEnv("CRYPTO_KEY")
+	if !ok {
+		return Config{}, errors.New("error: CRYPTO_KEY is not set!")
+	}
+	switch len(cryptoKey) {
+	case 16, 24, 32:
+	default:
+		return Config{}, errors.New("error: CRYPTO_KEY must be 16, 24 or 32 bytes long!")
+	}
+
+	logRetentionDays := 90
+	if v, ok := os.LookupEnv("LOG_RETENTION_DAYS"); ok {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			return Config{}, errors.New("error: LOG_RETENTION_DAYS must be a non-negative number!")
+		}
+		logRetentionDays = days
+	}
+
+	var moderationKeywords []string
+	if v, ok := os.LookupEnv("MODERATION_KEYWORDS"); ok && v != "" {
+		for _, keyword := range strings.Split(v, ",") {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				moderationKeywords = append(moderationKeywords, keyword)
+			}
+		}
+	}
+
 	return Config{
-		ClientURL:     clientURL,
-		DBURL:         dbURL,
-		Port:          port,
-		SecretKey:     secretKey,
-		Email:         email,
-		EmailPassword: pass,
+		ClientURL:          clientURL,
+		DBURL:              dbURL,
+		Port:               port,
+		SecretKey:          secretKey,
+		AdminSecretKey:     adminSecretKey,
+		AdminUsername:      os.Getenv("ADMIN_USERNAME"),
+		AdminPassword:      os.Getenv("ADMIN_PASSWORD"),
+		TokenType:          tokenType,
+		JWTFallback:        jwtFallback,
+		Email:              email,
+		EmailPassword:      pass,
+		CryptoKey:          cryptoKey,
+		ModerationKeywords: moderationKeywords,
+		LogRetentionDays:   logRetentionDays,
 	}, nil
 }
@@ -12,6 +12,11 @@ func TestNewConfig(t *testing.T) {
   require.NoError(t, os.Setenv("CLIENT_URL", "http://localhost:3000"))
   require.NoError(t, os.Setenv("DB_URL", "database_url"))
   require.NoError(t, os.Setenv("PORT", "6969"))
+  require.NoError(t, os.Setenv("SECRET_KEY", "secret_key"))
+  require.NoError(t, os.Setenv("ADMIN_SECRET_KEY", "admin_secret_key"))
+  require.NoError(t, os.Setenv("EMAIL", "email@example.com"))
+  require.NoError(t, os.Setenv("EMAIL_PASSWORD", "email_password"))
+  require.NoError(t, os.Setenv("CRYPTO_KEY", "0123456789abcdef"))
 
   cfg, err := NewConfig()
   require.NoError(t, err)
@@ -20,3 +25,70 @@ func TestNewConfig(t *testing.T) {
   assert.Equal(t, cfg.DBURL, "database_url")
   assert.Equal(t, cfg.Port, "6969")
 }
+
+// setRequiredEnv sets the variables NewConfig can't do without.
+func setRequiredEnv(t *testing.T) {
+  t.Setenv("CLIENT_URL", "http://localhost:3000")
+  t.Setenv("DB_URL", "database_url")
+  t.Setenv("SECRET_KEY", "secret_key")
+  t.Setenv("ADMIN_SECRET_KEY", "admin_secret_key")
+  t.Setenv("EMAIL", "email@example.com")
+  t.Setenv("EMAIL_PASSWORD", "email_password")
+  t.Setenv("CRYPTO_KEY", "0123456789abcdef")
+}
+
+func TestNewConfig_CryptoKey(t *testing.T) {
+  setRequiredEnv(t)
+
+  cfg, err := NewConfig()
+  require.NoError(t, err)
+  assert.Equal(t, "0123456789abcdef", cfg.CryptoKey)
+
+  t.Setenv("CRYPTO_KEY", "short")
+  _, err = NewConfig()
+  assert.Error(t, err)
+}
+
+func TestNewConfig_LogRetentionDays(t *testing.T) {
+  setRequiredEnv(t)
+
+  cfg, err := NewConfig()
+  require.NoError(t, err)
+  assert.Equal(t, 90, cfg.LogRetentionDays)
+
+  t.Setenv("LOG_RETENTION_DAYS", "0")
+  cfg, err = NewConfig()
+  require.NoError(t, err)
+  assert.Equal(t, 0, cfg.LogRetentionDays)
+
+  for _, v := range []string{"-1", "forever"} {
+    t.Setenv("LOG_RETENTION_DAYS", v)
+    _, err = NewConfig()
+    assert.Error(t, err, v)
+  }
+}
+
+func TestNewConfig_TokenType(t *testing.T) {
+  setRequiredEnv(t)
+
+  cfg, err := NewConfig()
+  require.NoError(t, err)
+  assert.Equal(t, "jwt", cfg.TokenType)
+  assert.True(t, cfg.JWTFallback)
+
+  t.Setenv("TOKEN_TYPE", "PASETO")
+  t.Setenv("TOKEN_JWT_FALLBACK", "false")
+  cfg, err = NewConfig()
+  require.NoError(t, err)
+  assert.Equal(t, "paseto", cfg.TokenType)
+  assert.False(t, cfg.JWTFallback)
+
+  t.Setenv("TOKEN_JWT_FALLBACK", "maybe")
+  _, err = NewConfig()
+  assert.Error(t, err)
+
+  t.Setenv("TOKEN_JWT_FALLBACK", "true")
+  t.Setenv("TOKEN_TYPE", "branca")
+  _, err = NewConfig()
+  assert.Error(t, err)
+}
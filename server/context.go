@@ -4,7 +4,10 @@ import "context"
 
 type contextKey int
 
-const userContextKey = contextKey(iota + 1)
+const (
+	userContextKey = contextKey(iota + 1)
+	adminContextKey
+)
 
 func NewContextWithUser(ctx context.Context, user *User) context.Context {
 	return context.WithValue(ctx, userContextKey, user)
@@ -21,3 +24,12 @@ func UserIDFromContext(ctx context.Context) uint {
 	}
 	return 0
 }
+
+func NewContextWithAdmin(ctx context.Context, admin *Admin) context.Context {
+	return context.WithValue(ctx, adminContextKey, admin)
+}
+
+func AdminFromContext(ctx context.Context) *Admin {
+	admin, _ := ctx.Value(adminContextKey).(*Admin)
+	return admin
+}
@@ -18,6 +18,7 @@ type Context struct {
 	Device     string    `json:"device"`
 	DeviceType string    `json:"device_type"`
 	IsTrusted  bool      `json:"is_trusted"`
+	IsPrimary  bool      `json:"is_primary"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
@@ -39,18 +40,13 @@ type ContextService interface {
 	DeleteContext(ctx context.Context, id uint) error
 }
 
+// ContextFilter has no filters on the device data, which is stored encrypted
+// with a random nonce and so can't be compared in queries.
 type ContextFilter struct {
-	ID         *uint   `json:"id"`
-	UserID     *uint   `json:"user_id"`
-	Email      *string `json:"email"`
-	IP         *string `json:"ip"`
-	Country    *string `json:"country"`
-	City       *string `json:"city"`
-	Browser    *string `json:"browser"`
-	Platform   *string `json:"platform"`
-	OS         *string `json:"os"`
-	Device     *string `json:"device"`
-	DeviceType *string `json:"device_type"`
+	ID        *uint   `json:"id"`
+	UserID    *uint   `json:"user_id"`
+	Email     *string `json:"email"`
+	IsPrimary *bool   `json:"is_primary"`
 
 	Limit  int `json:"limit"`
 	Offset int `json:"offset"`
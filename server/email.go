@@ -5,6 +5,12 @@ import (
 	"time"
 )
 
+// Values of Email.For.
+const (
+	EmailForSignup = "signup"
+	EmailForLogin  = "login"
+)
+
 type Email struct {
 	ID               uint      `json:"id"`
 	Email            string    `json:"email"`
@@ -19,6 +25,16 @@ type EmailService interface {
 	FindEmailVerificationByID(ctx context.Context, id uint) (*Email, error)
 	FindEmailVerifications(ctx context.Context, filter EmailFilter) ([]*Email, int, error)
 	CreateEmailVerification(ctx context.Context, email *Email) error
+
+	// ConsumeEmailVerification deletes the verifications matching email,
+	// code and purpose, so each can be used once, and returns the latest.
+	// An expired verification is consumed all the same but returns EINVALID.
+	ConsumeEmailVerification(ctx context.Context, email, code, purpose string) (*Email, error)
+
+	// ReserveEmailVerification creates email unless a verification for the
+	// same address and purpose was created within cooldown, in which case it
+	// returns that one with ECONFLICT. Concurrent calls are serialized.
+	ReserveEmailVerification(ctx context.Context, email *Email, cooldown time.Duration) (*Email, error)
 }
 
 type EmailFilter struct {
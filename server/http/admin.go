@@ -0,0 +1,241 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// POST /admin/signin
+func (s *Server) adminSignin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Admin struct {
+				Username string `json:"username" binding:"required"`
+				Password string `json:"password" binding:"required"`
+			} `json:"admin" binding:"required"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		admin, err := s.AdminService.Authenticate(c.Request.Context(), req.Admin.Username, req.Admin.Password)
+		if err != nil {
+			if sm.ErrorCode(err) == sm.ENOTAUTHORIZED {
+				s.logEvent(c, req.Admin.Username, sm.LogTypeAdminSignInFailed, sm.LogLevelWarn, "Admin sign-in failed: invalid credentials")
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <adminSignin> - authenticating the admin: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		accessToken, _, err := s.AdminTokenMaker.CreateToken(
+			admin.ID,
+			admin.Username,
+			time.Hour*6,
+		)
+		if err != nil {
+			log.Printf("ERROR <adminSignin> - creating access token: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		if err := s.AdminTokenService.CreateAdminToken(c.Request.Context(), &sm.AdminToken{
+			AdminID:     admin.ID,
+			AccessToken: accessToken,
+		}); err != nil {
+			log.Printf("ERROR <adminSignin> - creating new admin token on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		s.logEvent(c, admin.Username, sm.LogTypeAdminSignIn, sm.LogLevelInfo, "Admin signed in")
+
+		c.JSON(http.StatusOK, gin.H{
+			"access_token": accessToken,
+			"admin":        admin,
+		})
+	}
+}
+
+// POST /admin/logout
+func (s *Server) adminLogout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accessToken := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+		tk, _, err := s.AdminTokenService.FindAdminTokens(c.Request.Context(), sm.AdminTokenFilter{AccessToken: &accessToken})
+		if err != nil {
+			log.Printf("ERROR <adminLogout> - finding admin token from db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		} else if len(tk) == 0 {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Token not found",
+			})
+			return
+		}
+
+		if err := s.AdminTokenService.DeleteAdminToken(c.Request.Context(), tk[0].ID); err != nil {
+			if sm.ErrorCode(err) == sm.ENOTAUTHORIZED {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <adminLogout> - deleting admin token from db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Logout successful",
+		})
+	}
+}
+
+// GET /admin/me
+func (s *Server) getCurrentAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		admin := sm.AdminFromContext(c.Request.Context())
+		if admin == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Admin not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"admin": admin,
+		})
+	}
+}
+
+// POST /admin/admins
+func (s *Server) createAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Admin struct {
+				Username string `json:"username" binding:"required"`
+				Password string `json:"password" binding:"required,min=8"`
+			} `json:"admin" binding:"required"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		newAdmin := sm.Admin{
+			Username: req.Admin.Username,
+		}
+
+		if err := newAdmin.SetPassword(req.Admin.Password); err != nil {
+			log.Printf("ERROR <createAdmin> - hashing password: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		if err := s.AdminService.CreateAdmin(c.Request.Context(), &newAdmin); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ECONFLICT:
+				c.JSON(http.StatusConflict, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <createAdmin> - creating new admin on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Admin created successfully",
+			"admin":   newAdmin,
+		})
+	}
+}
+
+// DELETE /admin/users/:id/sessions
+//
+// Signs the user out on every device, effective immediately.
+func (s *Server) revokeUserSessions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid user id param",
+			})
+			return
+		}
+
+		n, err := s.TokenService.DeleteUserTokens(c.Request.Context(), uint(userID))
+		if err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <revokeUserSessions> - deleting tokens from db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+		s.tokens.revokeUser(uint(userID))
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Sessions revoked successfully",
+			"n":       n,
+		})
+	}
+}
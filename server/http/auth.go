@@ -1,10 +1,13 @@
 package http
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	sm "github.com/maliByatzes/socialmedia"
@@ -31,25 +34,173 @@ func isOldDataMatched(oldSuspiciouseContextData, userContextData utils.IPContext
 	return reflect.DeepEqual(oldSuspiciouseContextData, userContextData)
 }
 
-// run in go routine
-func (s *Server) verifyContextData(ctx *gin.Context, existingUser *sm.User) error {
-	userContextData, err := s.ContextService.FindContextByID(ctx.Request.Context(), existingUser.ID)
+// currentContextData looks up the context of the request once and keeps it
+// on c, since the geolocation lookup is slow.
+func (s *Server) currentContextData(c *gin.Context) (*utils.IPContext, error) {
+	if v, ok := c.Get("context_data"); ok {
+		return v.(*utils.IPContext), nil
+	}
+
+	data, err := utils.GetCurrentContextData(c.ClientIP(), c.Request)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	c.Set("context_data", data)
+
+	return data, nil
+}
 
-	currentContextData, err := utils.GetCurrentContextData(ctx.ClientIP(), ctx.Request)
+// sessionContextID returns the id of the user's context matching the device
+// of the request, creating it if needed. A session without a known device is
+// still allowed, so failures are only logged and return nil.
+func (s *Server) sessionContextID(c *gin.Context, user *sm.User) *uint {
+	ctx := c.Request.Context()
+
+	data, err := s.currentContextData(c)
 	if err != nil {
-		return err
+		log.Printf("ERROR <sessionContextID> - getting current context data: %v", err)
+		return nil
 	}
 
-	if isTrustedDevice(currentContextData, userContextData) {
+	contexts, _, err := s.ContextService.FindContexts(ctx, sm.ContextFilter{UserID: &user.ID})
+	if err != nil {
+		log.Printf("ERROR <sessionContextID> - finding contexts: %v", err)
 		return nil
 	}
 
-	// get Old suspcicious login context data
+	for _, context := range contexts {
+		if isTrustedDevice(data, context) {
+			return &context.ID
+		}
+	}
 
-	return nil
+	context := sm.Context{
+		UserID:     user.ID,
+		Email:      user.Email,
+		IP:         data.IP,
+		Country:    data.Country,
+		City:       data.City,
+		Browser:    data.Browser,
+		Platform:   data.Platform,
+		OS:         data.OS,
+		Device:     data.Device,
+		DeviceType: data.DeviceType,
+	}
+	if err := s.ContextService.CreateContext(ctx, &context); err != nil {
+		log.Printf("ERROR <sessionContextID> - creating context: %v", err)
+		return nil
+	}
+
+	return &context.ID
+}
+
+// verifyContextData compares the context of the request with the user's
+// primary context. When they do not match, the login is recorded as
+// suspicious and returned, otherwise nil is returned. The first context seen
+// for a user becomes the primary one.
+func (s *Server) verifyContextData(c *gin.Context, existingUser *sm.User) (*sm.SuspiciousLogin, error) {
+	ctx := c.Request.Context()
+
+	currentContextData, err := s.currentContextData(c)
+	if err != nil {
+		return nil, err
+	}
+
+	userContextData, err := s.ContextService.FindContextByUserID(ctx, existingUser.ID)
+	if sm.ErrorCode(err) == sm.ENOTFOUND {
+		return nil, s.savePrimaryContext(ctx, existingUser, currentContextData)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if isTrustedDevice(currentContextData, userContextData) {
+		return nil, nil
+	}
+
+	s.logEvent(c, existingUser.Email, sm.LogTypeContextMismatch, sm.LogLevelWarn, "Sign-in from a device that does not match the primary context")
+
+	sls, _, err := s.SuspiciousLoginService.FindSLs(ctx, sm.SLFilter{
+		UserID:     &existingUser.ID,
+		IP:         &currentContextData.IP,
+		Country:    &currentContextData.Country,
+		City:       &currentContextData.City,
+		Browser:    &currentContextData.Browser,
+		Platform:   &currentContextData.Platform,
+		OS:         &currentContextData.OS,
+		Device:     &currentContextData.Device,
+		DeviceType: &currentContextData.DeviceType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sls) == 0 {
+		sl := &sm.SuspiciousLogin{
+			UserID:             existingUser.ID,
+			Email:              existingUser.Email,
+			IP:                 currentContextData.IP,
+			Country:            currentContextData.Country,
+			City:               currentContextData.City,
+			Browser:            currentContextData.Browser,
+			Platform:           currentContextData.Platform,
+			OS:                 currentContextData.OS,
+			Device:             currentContextData.Device,
+			DeviceType:         currentContextData.DeviceType,
+			UnverifiedAttempts: 1,
+		}
+		if err := s.SuspiciousLoginService.CreateSL(ctx, sl); err != nil {
+			return nil, err
+		}
+		return sl, nil
+	}
+
+	// A device the user already verified or blocked is left as it is.
+	sl := sls[0]
+	if sl.IsTrusted || sl.IsBlocked {
+		return sl, nil
+	}
+
+	attempts := sl.UnverifiedAttempts + 1
+	return s.SuspiciousLoginService.UpdateSL(ctx, sl.ID, sm.SLUpdate{
+		UnverifiedAttempts: &attempts,
+	})
+}
+
+// savePrimaryContext records data as the primary context of user, replacing
+// the one stored before.
+func (s *Server) savePrimaryContext(ctx context.Context, user *sm.User, data *utils.IPContext) error {
+	primary, err := s.ContextService.FindContextByUserID(ctx, user.ID)
+	if sm.ErrorCode(err) == sm.ENOTFOUND {
+		return s.ContextService.CreateContext(ctx, &sm.Context{
+			UserID:     user.ID,
+			Email:      user.Email,
+			IP:         data.IP,
+			Country:    data.Country,
+			City:       data.City,
+			Browser:    data.Browser,
+			Platform:   data.Platform,
+			OS:         data.OS,
+			Device:     data.Device,
+			DeviceType: data.DeviceType,
+			IsTrusted:  true,
+			IsPrimary:  true,
+		})
+	} else if err != nil {
+		return err
+	}
+
+	_, err = s.ContextService.UpdateContext(ctx, primary.ID, sm.ContextUpdate{
+		Email:      &user.Email,
+		IP:         &data.IP,
+		Country:    &data.Country,
+		City:       &data.City,
+		Browser:    &data.Browser,
+		Platform:   &data.Platform,
+		OS:         &data.OS,
+		Device:     &data.Device,
+		DeviceType: &data.DeviceType,
+	})
+	return err
 }
 
 // GET /auth/context-data/primary
@@ -212,6 +363,19 @@ func (s *Server) deleteContextAuthData() gin.HandlerFunc {
 		}
 
 		if err := s.SuspiciousLoginService.DeleteSL(c.Request.Context(), uint(contextID)); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
 			log.Printf("ERROR <deleteContextAuthData> - deleting suspicous login from db: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Internal Server Error",
@@ -254,6 +418,19 @@ func (s *Server) blockContextAuthData() gin.HandlerFunc {
 		})
 
 		if err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
 			log.Printf("ERROR <blockContextAuthData> - updating suspicous login in db: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Internal Server Error",
@@ -261,6 +438,8 @@ func (s *Server) blockContextAuthData() gin.HandlerFunc {
 			return
 		}
 
+		s.logEvent(c, user.Email, sm.LogTypeBlock, sm.LogLevelWarn, fmt.Sprintf("Blocked login context %d", contextID))
+
 		c.JSON(http.StatusOK, gin.H{
 			"message": "Blocked successfully",
 		})
@@ -296,6 +475,19 @@ func (s *Server) unblockContextAuthData() gin.HandlerFunc {
 		})
 
 		if err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
 			log.Printf("ERROR <unblockContextAuthData> - updating suspicous login in db: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Internal Server Error",
@@ -308,3 +500,165 @@ func (s *Server) unblockContextAuthData() gin.HandlerFunc {
 		})
 	}
 }
+
+// POST /auth/verify-login
+//
+// Trusts the device of a suspicious login from the link in the login
+// verification email and signs the user in.
+func (s *Server) verifyLogin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			ID    uint   `json:"id" binding:"required"`
+			Code  string `json:"code" binding:"required"`
+			Email string `json:"email" binding:"required,email"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		user, sl, ok := s.consumeLoginVerification(c, "verifyLogin", req.ID, req.Code, req.Email)
+		if !ok {
+			return
+		}
+
+		bTrue := true
+		bFalse := false
+		if _, err := s.SuspiciousLoginService.UpdateSL(c.Request.Context(), sl.ID, sm.SLUpdate{
+			IsTrusted: &bTrue,
+			IsBlocked: &bFalse,
+		}); err != nil {
+			log.Printf("ERROR <verifyLogin> - updating suspicous login in db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		accessToken, refreshToken, err := s.createUserTokens(c, user)
+		if err != nil {
+			log.Printf("ERROR <verifyLogin> - creating tokens: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		s.logEvent(c, user.Email, sm.LogTypeSignIn, sm.LogLevelInfo, fmt.Sprintf("Signed in after verifying login context %d", sl.ID))
+
+		c.JSON(http.StatusOK, gin.H{
+			"access_token":            accessToken,
+			"refresh_token":           refreshToken,
+			"access_token_updated_at": time.Now(),
+			"user":                    user,
+		})
+	}
+}
+
+// POST /auth/block-device
+//
+// Blocks the device of a suspicious login from the link in the login
+// verification email. Later sign-ins from it are refused straight away.
+func (s *Server) blockDevice() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			ID    uint   `json:"id" binding:"required"`
+			Code  string `json:"code" binding:"required"`
+			Email string `json:"email" binding:"required,email"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		user, sl, ok := s.consumeLoginVerification(c, "blockDevice", req.ID, req.Code, req.Email)
+		if !ok {
+			return
+		}
+
+		bTrue := true
+		bFalse := false
+		if _, err := s.SuspiciousLoginService.UpdateSL(c.Request.Context(), sl.ID, sm.SLUpdate{
+			IsBlocked: &bTrue,
+			IsTrusted: &bFalse,
+		}); err != nil {
+			log.Printf("ERROR <blockDevice> - updating suspicous login in db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		s.logEvent(c, user.Email, sm.LogTypeBlock, sm.LogLevelWarn, fmt.Sprintf("Blocked login context %d from email", sl.ID))
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Device blocked successfully",
+		})
+	}
+}
+
+// consumeLoginVerification loads the suspicious login id and uses up the
+// login verification email sent for it with code. The user of the login is
+// added to the request context. It writes the error response and returns
+// false when the link is not valid.
+func (s *Server) consumeLoginVerification(c *gin.Context, name string, id uint, code, email string) (*sm.User, *sm.SuspiciousLogin, bool) {
+	ctx := c.Request.Context()
+
+	// The login is checked first, so a link with a wrong id does not use up
+	// the code.
+	sl, err := s.SuspiciousLoginService.FindSLByID(ctx, id)
+	if err != nil && sm.ErrorCode(err) != sm.ENOTFOUND {
+		log.Printf("ERROR <%s> - finding suspicous login by id: %v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal Server Error",
+		})
+		return nil, nil, false
+	} else if err != nil || sl.Email != email {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid or expired verification link",
+		})
+		return nil, nil, false
+	}
+
+	if _, err := s.EmailService.ConsumeEmailVerification(ctx, email, loginVerificationCode(sl.ID, code), sm.EmailForLogin); err != nil {
+		switch sm.ErrorCode(err) {
+		case sm.ENOTFOUND, sm.EINVALID:
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid or expired verification link",
+			})
+			return nil, nil, false
+		}
+
+		log.Printf("ERROR <%s> - consuming email verification: %v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal Server Error",
+		})
+		return nil, nil, false
+	}
+
+	user, err := s.UserService.FindUserByID(ctx, sl.UserID)
+	if err != nil {
+		if sm.ErrorCode(err) == sm.ENOTFOUND {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": sm.ErrorMessage(err),
+			})
+			return nil, nil, false
+		}
+
+		log.Printf("ERROR <%s> - finding user by id: %v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal Server Error",
+		})
+		return nil, nil, false
+	}
+
+	c.Request = c.Request.WithContext(sm.NewContextWithUser(ctx, user))
+
+	return user, sl, true
+}
@@ -0,0 +1,278 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// POST /posts/:id/comments
+func (s *Server) createComment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Comment struct {
+				Body     string `json:"body" binding:"required"`
+				ParentID *uint  `json:"parent_id"`
+			} `json:"comment" binding:"required"`
+		}
+
+		postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid post id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+
+		// Comments have no review queue, so they are refused when the
+		// content cannot be checked.
+		result, err := s.ContentModerator.Moderate(c.Request.Context(), req.Comment.Body)
+		if err != nil {
+			log.Printf("ERROR <createComment> - moderating comment body: %v", err)
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "Content moderation is unavailable, please try again later",
+			})
+			return
+		} else if result.Flagged {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Comment rejected by content moderation",
+				"reasons": result.Reasons,
+			})
+			return
+		}
+
+		newComment := sm.Comment{
+			Body:     req.Comment.Body,
+			UserID:   user.ID,
+			PostID:   uint(postID),
+			ParentID: req.Comment.ParentID,
+		}
+
+		if err := s.CommentService.CreateComment(c.Request.Context(), &newComment); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <createComment> - creating new comment on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Comment created successfully",
+			"comment": newComment,
+		})
+	}
+}
+
+// GET /posts/:id/comments?parent_id=&limit=&offset=
+//
+// Without parent_id only top-level comments are listed.
+func (s *Server) getComments() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query struct {
+			ParentID uint `form:"parent_id"`
+			Limit    int  `form:"limit"`
+			Offset   int  `form:"offset"`
+		}
+
+		postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid post id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		if _, err := s.PostService.FindPostByID(c.Request.Context(), uint(postID)); err != nil {
+			if sm.ErrorCode(err) == sm.ENOTFOUND {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <getComments> - finding post by id: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		id := uint(postID)
+		comments, n, err := s.CommentService.FindComments(c.Request.Context(), sm.CommentFilter{
+			PostID:   &id,
+			ParentID: &query.ParentID,
+			Limit:    query.Limit,
+			Offset:   query.Offset,
+		})
+		if err != nil {
+			log.Printf("ERROR <getComments> - finding comments: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":        n,
+			"comments": comments,
+		})
+	}
+}
+
+// PATCH /comments/:id
+func (s *Server) updateComment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Comment struct {
+				Body *string `json:"body"`
+			} `json:"comment" binding:"required"`
+		}
+
+		commentID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid comment id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		if req.Comment.Body != nil {
+			result, err := s.ContentModerator.Moderate(c.Request.Context(), *req.Comment.Body)
+			if err != nil {
+				log.Printf("ERROR <updateComment> - moderating comment body: %v", err)
+				c.JSON(http.StatusServiceUnavailable, gin.H{
+					"error": "Content moderation is unavailable, please try again later",
+				})
+				return
+			} else if result.Flagged {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error":   "Comment rejected by content moderation",
+					"reasons": result.Reasons,
+				})
+				return
+			}
+		}
+
+		updatedComment, err := s.CommentService.UpdateComment(c.Request.Context(), uint(commentID), sm.CommentUpdate{
+			Body: req.Comment.Body,
+		})
+		if err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <updateComment> - updating comment on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Comment updated successfully",
+			"comment": updatedComment,
+		})
+	}
+}
+
+// DELETE /comments/:id
+func (s *Server) deleteComment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		commentID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid comment id param",
+			})
+			return
+		}
+
+		if err := s.CommentService.DeleteComment(c.Request.Context(), uint(commentID)); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <deleteComment> - deleting comment from db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Comment deleted successfully",
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// GET /communities?name=&limit=&offset=
+func (s *Server) getCommunities() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query struct {
+			Name   *string `form:"name"`
+			Limit  int     `form:"limit"`
+			Offset int     `form:"offset"`
+		}
+
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		communities, n, err := s.CommunityService.FindCommunities(c.Request.Context(), sm.CommunityFilter{
+			Name:   query.Name,
+			Limit:  query.Limit,
+			Offset: query.Offset,
+		})
+		if err != nil {
+			log.Printf("ERROR <getCommunities> - finding communities: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":           n,
+			"communities": communities,
+		})
+	}
+}
+
+// GET /communities/:id
+func (s *Server) getCommunity() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		community, err := s.CommunityService.FindCommunityByID(c.Request.Context(), uint(communityID))
+		if err != nil {
+			if sm.ErrorCode(err) == sm.ENOTFOUND {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <getCommunity> - finding community by id: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"community": community,
+		})
+	}
+}
+
+// POST /communities
+func (s *Server) createCommunity() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Community struct {
+				Name                 string `json:"name" binding:"required"`
+				Description          string `json:"description"`
+				Banner               string `json:"banner"`
+				RequiresPostApproval bool   `json:"requires_post_approval"`
+			} `json:"community" binding:"required"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		newCommunity := sm.Community{
+			Name:                 req.Community.Name,
+			Description:          req.Community.Description,
+			Banner:               req.Community.Banner,
+			RequiresPostApproval: req.Community.RequiresPostApproval,
+		}
+
+		if err := s.CommunityService.CreateCommunity(c.Request.Context(), &newCommunity); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ECONFLICT:
+				c.JSON(http.StatusConflict, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <createCommunity> - creating new community on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message":   "Community created successfully",
+			"community": newCommunity,
+		})
+	}
+}
+
+// PATCH /communities/:id
+func (s *Server) updateCommunity() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Community struct {
+				Name                 *string `json:"name"`
+				Description          *string `json:"description"`
+				Banner               *string `json:"banner"`
+				RequiresPostApproval *bool   `json:"requires_post_approval"`
+			} `json:"community" binding:"required"`
+		}
+
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		updatedCommunity, err := s.CommunityService.UpdateCommunity(c.Request.Context(), uint(communityID), sm.CommunityUpdate{
+			Name:                 req.Community.Name,
+			Description:          req.Community.Description,
+			Banner:               req.Community.Banner,
+			RequiresPostApproval: req.Community.RequiresPostApproval,
+		})
+		if err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ECONFLICT:
+				c.JSON(http.StatusConflict, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <updateCommunity> - updating community on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":   "Community updated successfully",
+			"community": updatedCommunity,
+		})
+	}
+}
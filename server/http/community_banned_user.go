@@ -0,0 +1,167 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// GET /communities/:id/bans?limit=&offset=
+func (s *Server) getCommunityBans() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query struct {
+			Limit  int `form:"limit"`
+			Offset int `form:"offset"`
+		}
+
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		id := uint(communityID)
+		bans, n, err := s.CBUService.FindCBUs(c.Request.Context(), sm.CBUFilter{
+			CommunityID: &id,
+			Limit:       query.Limit,
+			Offset:      query.Offset,
+		})
+		if err != nil {
+			log.Printf("ERROR <getCommunityBans> - finding banned users: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":    n,
+			"bans": bans,
+		})
+	}
+}
+
+// POST /communities/:id/bans
+func (s *Server) banUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Ban struct {
+				UserID uint `json:"user_id" binding:"required"`
+			} `json:"ban" binding:"required"`
+		}
+
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		newBan := sm.CBU{
+			CommunityID: uint(communityID),
+			UserID:      req.Ban.UserID,
+		}
+
+		if err := s.CBUService.CreateCBU(c.Request.Context(), &newBan); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ECONFLICT:
+				c.JSON(http.StatusConflict, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <banUser> - creating banned user on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "User banned successfully",
+			"ban":     newBan,
+		})
+	}
+}
+
+// DELETE /communities/:id/bans/:userId
+func (s *Server) unbanUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		userID, err := strconv.ParseUint(c.Param("userId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid user id param",
+			})
+			return
+		}
+
+		if err := s.CBUService.DeleteCBU(c.Request.Context(), uint(communityID), uint(userID)); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <unbanUser> - deleting banned user from db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "User unbanned successfully",
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// POST /communities/:id/join
+func (s *Server) joinCommunity() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+
+		newMember := sm.CommunityMember{
+			CommunityID: uint(communityID),
+			UserID:      user.ID,
+		}
+
+		if err := s.CommunityMemberService.CreateCommunityMember(c.Request.Context(), &newMember); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ECONFLICT:
+				c.JSON(http.StatusConflict, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <joinCommunity> - creating community member on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Joined community successfully",
+			"member":  newMember,
+		})
+	}
+}
+
+// POST /communities/:id/leave
+func (s *Server) leaveCommunity() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+
+		if err := s.CommunityMemberService.DeleteCommunityMember(c.Request.Context(), uint(communityID), user.ID); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <leaveCommunity> - deleting community member from db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Left community successfully",
+		})
+	}
+}
+
+// GET /communities/:id/members?limit=&offset=
+func (s *Server) getCommunityMembers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query struct {
+			Limit  int `form:"limit"`
+			Offset int `form:"offset"`
+		}
+
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		id := uint(communityID)
+		members, n, err := s.CommunityMemberService.FindCommunityMembers(c.Request.Context(), sm.CommunityMemberFilter{
+			CommunityID: &id,
+			Limit:       query.Limit,
+			Offset:      query.Offset,
+		})
+		if err != nil {
+			log.Printf("ERROR <getCommunityMembers> - finding community members: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":       n,
+			"members": members,
+		})
+	}
+}
+
+// GET /communities/:id/moderators
+func (s *Server) getCommunityModerators() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		id, bTrue := uint(communityID), true
+		moderators, n, err := s.CommunityMemberService.FindCommunityMembers(c.Request.Context(), sm.CommunityMemberFilter{
+			CommunityID: &id,
+			IsModerator: &bTrue,
+		})
+		if err != nil {
+			log.Printf("ERROR <getCommunityModerators> - finding community moderators: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":          n,
+			"moderators": moderators,
+		})
+	}
+}
+
+// PUT /communities/:id/moderators/:userId
+// DELETE /communities/:id/moderators/:userId
+func (s *Server) setCommunityModerator(isModerator bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		userID, err := strconv.ParseUint(c.Param("userId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid user id param",
+			})
+			return
+		}
+
+		member, err := s.CommunityMemberService.UpdateCommunityMember(c.Request.Context(), uint(communityID), uint(userID), sm.CommunityMemberUpdate{
+			IsModerator: &isModerator,
+		})
+		if err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <setCommunityModerator> - updating community member on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		message := "Moderator removed successfully"
+		if isModerator {
+			message = "Moderator added successfully"
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": message,
+			"member":  member,
+		})
+	}
+}
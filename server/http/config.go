@@ -0,0 +1,78 @@
+package http
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// GET /admin/config
+func (s *Server) getConfig() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		config, err := s.ConfigService.FindConfig(c.Request.Context())
+		if err != nil {
+			log.Printf("ERROR <getConfig> - finding config: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"config": config,
+		})
+	}
+}
+
+// PATCH /admin/config
+func (s *Server) updateConfig() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Config struct {
+				UsePerspectiveAPI                *bool   `json:"use_perspective_api"`
+				CategoryFilteringServiceProvider *string `json:"category_filtering_service_provider"`
+				CategoryFilteringRequestTimeout  *int    `json:"category_filtering_request_timeout"`
+			} `json:"config" binding:"required"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		config, err := s.ConfigService.UpdateConfig(c.Request.Context(), sm.ConfigUpdate{
+			UsePerspectiveAPI:                req.Config.UsePerspectiveAPI,
+			CategoryFilteringServiceProvider: req.Config.CategoryFilteringServiceProvider,
+			CategoryFilteringRequestTimeout:  req.Config.CategoryFilteringRequestTimeout,
+		})
+		if err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <updateConfig> - updating config on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Config updated successfully",
+			"config":  config,
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// logEvent records a security or auth event in the logs table. It runs in
+// the background so the request is not held up by it.
+func (s *Server) logEvent(c *gin.Context, email, eventType, level, message string) {
+	requestContext, err := json.Marshal(map[string]string{
+		"ip":         c.ClientIP(),
+		"user_agent": c.Request.UserAgent(),
+		"path":       c.Request.URL.Path,
+	})
+	if err != nil {
+		log.Printf("ERROR <logEvent> - encoding request context: %v", err)
+		return
+	}
+
+	event := sm.Log{
+		Email:   email,
+		Context: string(requestContext),
+		Message: message,
+		Type:    eventType,
+		Level:   level,
+	}
+
+	go func() {
+		if err := s.LogService.CreateLog(context.Background(), &event); err != nil {
+			log.Printf("ERROR <logEvent> - creating new log on db: %v", err)
+		}
+	}()
+}
+
+// purgeLogs deletes logs older than retention every interval, until ctx is
+// done.
+func (s *Server) purgeLogs(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := s.LogService.DeleteLogsBefore(ctx, time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("ERROR <purgeLogs> - deleting old logs from db: %v", err)
+		} else if n > 0 {
+			log.Printf("Purged %d logs older than %s", n, retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GET /admin/logs?email=&type=&level=&from=&to=&limit=&offset=
+//
+// from and to are RFC 3339 timestamps.
+func (s *Server) getLogs() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query struct {
+			Email  string `form:"email"`
+			Type   string `form:"type"`
+			Level  string `form:"level"`
+			From   string `form:"from"`
+			To     string `form:"to"`
+			Limit  int    `form:"limit"`
+			Offset int    `form:"offset"`
+		}
+
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		filter := sm.LogFilter{
+			Limit:  query.Limit,
+			Offset: query.Offset,
+		}
+		if query.Email != "" {
+			filter.Email = &query.Email
+		}
+		if query.Type != "" {
+			filter.Type = &query.Type
+		}
+		if query.Level != "" {
+			filter.Level = &query.Level
+		}
+		if query.From != "" {
+			from, err := time.Parse(time.RFC3339, query.From)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": "Invalid from param",
+				})
+				return
+			}
+			filter.From = &from
+		}
+		if query.To != "" {
+			to, err := time.Parse(time.RFC3339, query.To)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": "Invalid to param",
+				})
+				return
+			}
+			filter.To = &to
+		}
+
+		logs, n, err := s.LogService.FindLogs(c.Request.Context(), filter)
+		if err != nil {
+			log.Printf("ERROR <getLogs> - finding logs: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":    n,
+			"logs": logs,
+		})
+	}
+}
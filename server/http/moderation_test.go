@@ -0,0 +1,87 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+	"github.com/maliByatzes/socialmedia/moderation"
+)
+
+// postService fails the test when a post is updated.
+type postService struct {
+	sm.PostService
+	t *testing.T
+}
+
+func (s *postService) UpdatePost(ctx context.Context, id uint, upd sm.PostUpdate) (*sm.Post, error) {
+	s.t.Error("unexpected post update")
+	return nil, sm.Errorf(sm.EINVALID, "unexpected post update")
+}
+
+// commentService fails the test when a comment is updated.
+type commentService struct {
+	sm.CommentService
+	t *testing.T
+}
+
+func (s *commentService) UpdateComment(ctx context.Context, id uint, upd sm.CommentUpdate) (*sm.Comment, error) {
+	s.t.Error("unexpected comment update")
+	return nil, sm.Errorf(sm.EINVALID, "unexpected comment update")
+}
+
+// newModerationTestServer returns a server whose content moderator flags
+// "badword", with a signed in user on every request.
+func newModerationTestServer(t *testing.T) *Server {
+	gin.SetMode(gin.TestMode)
+
+	keywordModerator, err := moderation.NewKeywordModerator([]string{"badword"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{
+		Router:           gin.New(),
+		ContentModerator: keywordModerator,
+		PostService:      &postService{t: t},
+		CommentService:   &commentService{t: t},
+	}
+	s.Router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(sm.NewContextWithUser(c.Request.Context(), &sm.User{ID: 1}))
+	})
+	s.Router.PATCH("/posts/:id", s.updatePost())
+	s.Router.PATCH("/comments/:id", s.updateComment())
+	return s
+}
+
+func TestUpdatePost_Moderation(t *testing.T) {
+	s := newModerationTestServer(t)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPatch, "/posts/1", strings.NewReader(`{"post":{"content":"a BadWord edit"}}`))
+	s.Router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status %d: %s", w.Code, w.Body)
+	} else if !strings.Contains(w.Body.String(), "Post rejected by content moderation") {
+		t.Fatalf("unexpected body: %s", w.Body)
+	}
+}
+
+func TestUpdateComment_Moderation(t *testing.T) {
+	s := newModerationTestServer(t)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPatch, "/comments/1", strings.NewReader(`{"comment":{"body":"a BadWord edit"}}`))
+	s.Router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status %d: %s", w.Code, w.Body)
+	} else if !strings.Contains(w.Body.String(), "Comment rejected by content moderation") {
+		t.Fatalf("unexpected body: %s", w.Body)
+	}
+}
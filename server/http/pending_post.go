@@ -0,0 +1,343 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+	"github.com/maliByatzes/socialmedia/mail"
+)
+
+// submitPendingPost queues a post for approval. It is called by createPost
+// when the community requires posts to be approved.
+func (s *Server) submitPendingPost(c *gin.Context, post *sm.PendingPost) {
+	if err := s.PendingPostService.CreatePendingPost(c.Request.Context(), post); err != nil {
+		switch sm.ErrorCode(err) {
+		case sm.EINVALID:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": sm.ErrorMessage(err),
+			})
+			return
+		case sm.ENOTFOUND:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": sm.ErrorMessage(err),
+			})
+			return
+		case sm.ENOTAUTHORIZED:
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": sm.ErrorMessage(err),
+			})
+			return
+		}
+
+		log.Printf("ERROR <createPost> - creating new pending post on db: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal Server Error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":      "Post submitted for approval",
+		"pending_post": post,
+	})
+}
+
+// GET /users/me/pending-posts?status=&limit=&offset=
+func (s *Server) getMyPendingPosts() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query struct {
+			Status string `form:"status"`
+			Limit  int    `form:"limit"`
+			Offset int    `form:"offset"`
+		}
+
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+
+		filter := sm.PendingPostFilter{
+			UserID: &user.ID,
+			Limit:  query.Limit,
+			Offset: query.Offset,
+		}
+		if query.Status != "" {
+			filter.Status = &query.Status
+		}
+
+		posts, n, err := s.PendingPostService.FindPendingPosts(c.Request.Context(), filter)
+		if err != nil {
+			log.Printf("ERROR <getMyPendingPosts> - finding pending posts: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":             n,
+			"pending_posts": posts,
+		})
+	}
+}
+
+// GET /communities/:id/pending-posts?limit=&offset=
+func (s *Server) getPendingPosts() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query struct {
+			Limit  int `form:"limit"`
+			Offset int `form:"offset"`
+		}
+
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		id, status := uint(communityID), sm.PendingPostStatusPending
+		posts, n, err := s.PendingPostService.FindPendingPosts(c.Request.Context(), sm.PendingPostFilter{
+			CommunityID: &id,
+			Status:      &status,
+			Limit:       query.Limit,
+			Offset:      query.Offset,
+		})
+		if err != nil {
+			log.Printf("ERROR <getPendingPosts> - finding pending posts: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":             n,
+			"pending_posts": posts,
+		})
+	}
+}
+
+// POST /communities/:id/pending-posts/:postId/approve
+func (s *Server) approvePendingPost() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		pending, ok := s.findCommunityPendingPost(c, "approvePendingPost")
+		if !ok {
+			return
+		}
+
+		post, err := s.PendingPostService.ApprovePendingPost(c.Request.Context(), pending.ID)
+		if err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ECONFLICT:
+				c.JSON(http.StatusConflict, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <approvePendingPost> - approving pending post on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		content := pendingPostApprovedHTML(fmt.Sprintf("%s/posts/%d", s.cfg.ClientURL, post.ID))
+		s.notifyPendingPostAuthor(pending, "Your post has been approved", content)
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Post approved successfully",
+			"post":    post,
+		})
+	}
+}
+
+// POST /communities/:id/pending-posts/:postId/reject
+func (s *Server) rejectPendingPost() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Reason string `json:"reason"`
+		}
+
+		// The reason is optional, so an empty body is fine.
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": err.Error(),
+				})
+				return
+			}
+		}
+
+		pending, ok := s.findCommunityPendingPost(c, "rejectPendingPost")
+		if !ok {
+			return
+		}
+
+		rejected, err := s.PendingPostService.RejectPendingPost(c.Request.Context(), pending.ID)
+		if err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ECONFLICT:
+				c.JSON(http.StatusConflict, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <rejectPendingPost> - rejecting pending post on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		content := pendingPostRejectedHTML(req.Reason)
+		s.notifyPendingPostAuthor(pending, "Your post has been rejected", content)
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":      "Post rejected successfully",
+			"pending_post": rejected,
+		})
+	}
+}
+
+// findCommunityPendingPost loads the pending post in the postId param and
+// checks it belongs to the community in the id param. It writes the error
+// response and returns false when it cannot.
+func (s *Server) findCommunityPendingPost(c *gin.Context, name string) (*sm.PendingPost, bool) {
+	communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid community id param",
+		})
+		return nil, false
+	}
+
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid post id param",
+		})
+		return nil, false
+	}
+
+	pending, err := s.PendingPostService.FindPendingPostByID(c.Request.Context(), uint(postID))
+	if err != nil {
+		if sm.ErrorCode(err) == sm.ENOTFOUND {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": sm.ErrorMessage(err),
+			})
+			return nil, false
+		}
+
+		log.Printf("ERROR <%s> - finding pending post by id: %v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal Server Error",
+		})
+		return nil, false
+	} else if pending.CommunityID != uint(communityID) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Pending post not found in this community",
+		})
+		return nil, false
+	}
+
+	return pending, true
+}
+
+// notifyPendingPostAuthor emails the author of the pending post in the
+// background.
+func (s *Server) notifyPendingPostAuthor(pending *sm.PendingPost, subject, content string) {
+	go func() {
+		author, err := s.UserService.FindUserByID(context.Background(), pending.UserID)
+		if err != nil {
+			log.Printf("ERROR <notifyPendingPostAuthor> - finding user by id: %v", err)
+			return
+		}
+
+		sender := mail.NewGmailSender("SocialMedia", s.cfg.Email, s.cfg.EmailPassword)
+		if err := sender.SendEmail(subject, content, []string{author.Email}, nil, nil); err != nil {
+			log.Printf("ERROR <notifyPendingPostAuthor> - sending email to user: %v", err)
+		}
+	}()
+}
+
+func pendingPostApprovedHTML(postLink string) string {
+	return fmt.Sprintf(`
+	<div style="background-color: #F4F4F4; padding: 20px;">
+      <div style="background-color: #fff; padding: 20px; border-radius: 10px;">
+        <h1 style="color: black; font-size: 24px; margin-bottom: 20px;">Your post has been approved</h1>
+        <p>A moderator has approved your post and it is now visible in the community.</p>
+        <div style="text-align: center;">
+          <a href="%s" style="display: inline-block; padding: 10px 20px; background-color: #1da1f2; color: #fff; text-decoration: none; border-radius: 5px; margin-bottom: 20px;">View Post</a>
+        </div>
+      </div>
+    </div>
+	`, postLink)
+}
+
+func pendingPostRejectedHTML(reason string) string {
+	if reason == "" {
+		reason = "No reason was given."
+	}
+
+	return fmt.Sprintf(`
+	<div style="background-color: #F4F4F4; padding: 20px;">
+      <div style="background-color: #fff; padding: 20px; border-radius: 10px;">
+        <h1 style="color: black; font-size: 24px; margin-bottom: 20px;">Your post has been rejected</h1>
+        <p>A moderator has reviewed your post and decided not to publish it.</p>
+        <p><strong>Reason:</strong> %s</p>
+      </div>
+    </div>
+	`, html.EscapeString(reason))
+}
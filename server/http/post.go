@@ -0,0 +1,318 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// POST /posts
+func (s *Server) createPost() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Post struct {
+				Content     string `json:"content"`
+				FileURL     string `json:"file_url"`
+				CommunityID uint   `json:"community_id" binding:"required"`
+			} `json:"post" binding:"required"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+
+		// Posts that could not be checked are left to the moderators.
+		needsApproval := false
+		result, err := s.ContentModerator.Moderate(c.Request.Context(), req.Post.Content)
+		if err != nil {
+			log.Printf("ERROR <createPost> - moderating post content: %v", err)
+			needsApproval = true
+		} else if result.Flagged {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Post rejected by content moderation",
+				"reasons": result.Reasons,
+			})
+			return
+		}
+
+		com, err := s.CommunityService.FindCommunityByID(c.Request.Context(), req.Post.CommunityID)
+		if err != nil {
+			if sm.ErrorCode(err) == sm.ENOTFOUND {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <createPost> - finding community by id: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		if com.RequiresPostApproval && !needsApproval {
+			ok, err := s.isCommunityModerator(c.Request.Context(), com.ID, user)
+			if err != nil {
+				log.Printf("ERROR <createPost> - finding community members: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Internal Server Error",
+				})
+				return
+			}
+			needsApproval = !ok
+		}
+
+		if needsApproval {
+			s.submitPendingPost(c, &sm.PendingPost{
+				Content:     req.Post.Content,
+				FileURL:     req.Post.FileURL,
+				CommunityID: com.ID,
+				UserID:      user.ID,
+			})
+			return
+		}
+
+		newPost := sm.Post{
+			Content:     req.Post.Content,
+			FileURL:     req.Post.FileURL,
+			CommunityID: req.Post.CommunityID,
+			UserID:      user.ID,
+		}
+
+		if err := s.PostService.CreatePost(c.Request.Context(), &newPost); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <createPost> - creating new post on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Post created successfully",
+			"post":    newPost,
+		})
+	}
+}
+
+// GET /posts/:id
+func (s *Server) getPost() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid post id param",
+			})
+			return
+		}
+
+		post, err := s.PostService.FindPostByID(c.Request.Context(), uint(postID))
+		if err != nil {
+			if sm.ErrorCode(err) == sm.ENOTFOUND {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <getPost> - finding post by id: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"post": post,
+		})
+	}
+}
+
+// GET /posts?community_id=&user_id=&limit=&offset=
+func (s *Server) getPosts() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query struct {
+			CommunityID *uint `form:"community_id"`
+			UserID      *uint `form:"user_id"`
+			Limit       int   `form:"limit"`
+			Offset      int   `form:"offset"`
+		}
+
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		posts, n, err := s.PostService.FindPosts(c.Request.Context(), sm.PostFilter{
+			CommunityID: query.CommunityID,
+			UserID:      query.UserID,
+			Limit:       query.Limit,
+			Offset:      query.Offset,
+		})
+		if err != nil {
+			log.Printf("ERROR <getPosts> - finding posts: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":     n,
+			"posts": posts,
+		})
+	}
+}
+
+// PATCH /posts/:id
+func (s *Server) updatePost() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Post struct {
+				Content *string `json:"content"`
+				FileURL *string `json:"file_url"`
+			} `json:"post" binding:"required"`
+		}
+
+		postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid post id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		// Edits are moderated like new posts. There is no review queue for
+		// them, so they are refused when the content cannot be checked.
+		if req.Post.Content != nil {
+			result, err := s.ContentModerator.Moderate(c.Request.Context(), *req.Post.Content)
+			if err != nil {
+				log.Printf("ERROR <updatePost> - moderating post content: %v", err)
+				c.JSON(http.StatusServiceUnavailable, gin.H{
+					"error": "Content moderation is unavailable, please try again later",
+				})
+				return
+			} else if result.Flagged {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error":   "Post rejected by content moderation",
+					"reasons": result.Reasons,
+				})
+				return
+			}
+		}
+
+		updatedPost, err := s.PostService.UpdatePost(c.Request.Context(), uint(postID), sm.PostUpdate{
+			Content: req.Post.Content,
+			FileURL: req.Post.FileURL,
+		})
+		if err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <updatePost> - updating post on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Post updated successfully",
+			"post":    updatedPost,
+		})
+	}
+}
+
+// DELETE /posts/:id
+func (s *Server) deletePost() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid post id param",
+			})
+			return
+		}
+
+		if err := s.PostService.DeletePost(c.Request.Context(), uint(postID)); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <deletePost> - deleting post from db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Post deleted successfully",
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// POST /posts/:id/like
+func (s *Server) likePost() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid post id param",
+			})
+			return
+		}
+
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+
+		if err := s.PostLikeService.CreatePostLike(c.Request.Context(), &sm.PostLike{
+			PostID: uint(postID),
+			UserID: user.ID,
+		}); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ECONFLICT:
+				c.JSON(http.StatusConflict, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <likePost> - creating post like on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		s.respondWithPost(c, "likePost", uint(postID), "Post liked successfully")
+	}
+}
+
+// DELETE /posts/:id/like
+func (s *Server) unlikePost() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid post id param",
+			})
+			return
+		}
+
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+
+		if err := s.PostLikeService.DeletePostLike(c.Request.Context(), uint(postID), user.ID); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <unlikePost> - deleting post like from db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		s.respondWithPost(c, "unlikePost", uint(postID), "Post unliked successfully")
+	}
+}
+
+// GET /posts/:id/likes?limit=&offset=
+func (s *Server) getPostLikes() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query struct {
+			Limit  int `form:"limit"`
+			Offset int `form:"offset"`
+		}
+
+		postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid post id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		id := uint(postID)
+		likes, n, err := s.PostLikeService.FindPostLikes(c.Request.Context(), sm.PostLikeFilter{
+			PostID: &id,
+			Fill:   true,
+			Limit:  query.Limit,
+			Offset: query.Offset,
+		})
+		if err != nil {
+			log.Printf("ERROR <getPostLikes> - finding post likes: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":     n,
+			"likes": likes,
+		})
+	}
+}
+
+// respondWithPost writes the post with its refreshed like columns.
+func (s *Server) respondWithPost(c *gin.Context, name string, postID uint, message string) {
+	post, err := s.PostService.FindPostByID(c.Request.Context(), postID)
+	if err != nil {
+		log.Printf("ERROR <%s> - finding post by id: %v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal Server Error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"post":    post,
+	})
+}
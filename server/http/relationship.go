@@ -0,0 +1,191 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// POST /users/:id/follow
+func (s *Server) followUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid user id param",
+			})
+			return
+		}
+
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+
+		newRelationship := sm.Relationship{
+			FollowerID:  user.ID,
+			FollowingID: uint(userID),
+		}
+
+		if err := s.RelationshipService.CreateRelationship(c.Request.Context(), &newRelationship); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ECONFLICT:
+				c.JSON(http.StatusConflict, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <followUser> - creating relationship on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message":      "User followed successfully",
+			"relationship": newRelationship,
+		})
+	}
+}
+
+// DELETE /users/:id/follow
+func (s *Server) unfollowUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid user id param",
+			})
+			return
+		}
+
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+
+		followingID := uint(userID)
+		rs, _, err := s.RelationshipService.FindRelationships(c.Request.Context(), sm.RelationshipFilter{
+			FollowerID:  &user.ID,
+			FollowingID: &followingID,
+		})
+		if err != nil {
+			log.Printf("ERROR <unfollowUser> - finding relationship: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		} else if len(rs) == 0 {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "You are not following this user",
+			})
+			return
+		}
+
+		if err := s.RelationshipService.DeleteRelationship(c.Request.Context(), rs[0].ID); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <unfollowUser> - deleting relationship from db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "User unfollowed successfully",
+		})
+	}
+}
+
+// GET /users/:id/followers?limit=&offset=
+func (s *Server) getFollowers() gin.HandlerFunc {
+	return s.getRelatedUsers("getFollowers", "followers", func(id uint) sm.UserFilter {
+		return sm.UserFilter{FollowersOf: &id}
+	})
+}
+
+// GET /users/:id/following?limit=&offset=
+func (s *Server) getFollowing() gin.HandlerFunc {
+	return s.getRelatedUsers("getFollowing", "following", func(id uint) sm.UserFilter {
+		return sm.UserFilter{FollowedBy: &id}
+	})
+}
+
+func (s *Server) getRelatedUsers(name, key string, filterFn func(id uint) sm.UserFilter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query struct {
+			Limit  int `form:"limit"`
+			Offset int `form:"offset"`
+		}
+
+		userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid user id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		filter := filterFn(uint(userID))
+		filter.Limit, filter.Offset = query.Limit, query.Offset
+
+		users, n, err := s.UserService.FindUsers(c.Request.Context(), filter)
+		if err != nil {
+			log.Printf("ERROR <%s> - finding users: %v", name, err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n": n,
+			key: users,
+		})
+	}
+}
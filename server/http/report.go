@@ -0,0 +1,275 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// POST /posts/:id/report
+func (s *Server) reportPost() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Report struct {
+				Reason string `json:"reason" binding:"required"`
+			} `json:"report" binding:"required"`
+		}
+
+		postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid post id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+
+		newReport := sm.Report{
+			PostID:     uint(postID),
+			ReportedBy: user.ID,
+			Reason:     req.Report.Reason,
+		}
+
+		if err := s.ReportService.CreateReport(c.Request.Context(), &newReport); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ECONFLICT:
+				c.JSON(http.StatusConflict, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <reportPost> - creating new report on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Post reported successfully",
+			"report":  newReport,
+		})
+	}
+}
+
+// GET /communities/:id/reports?status=&limit=&offset=
+//
+// Lists the reported posts of the community with their report counts. Only
+// open reports are listed unless another status is given.
+func (s *Server) getReportQueue() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query struct {
+			Status string `form:"status"`
+			Limit  int    `form:"limit"`
+			Offset int    `form:"offset"`
+		}
+
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		if query.Status == "" {
+			query.Status = sm.ReportStatusOpen
+		}
+
+		id := uint(communityID)
+		reportedPosts, n, err := s.ReportService.FindReportedPosts(c.Request.Context(), sm.ReportFilter{
+			CommunityID: &id,
+			Status:      &query.Status,
+			Limit:       query.Limit,
+			Offset:      query.Offset,
+		})
+		if err != nil {
+			log.Printf("ERROR <getReportQueue> - finding reported posts: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":       n,
+			"reports": reportedPosts,
+		})
+	}
+}
+
+// GET /communities/:id/reports/:postId?limit=&offset=
+func (s *Server) getPostReports() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query struct {
+			Limit  int `form:"limit"`
+			Offset int `form:"offset"`
+		}
+
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		postID, err := strconv.ParseUint(c.Param("postId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid post id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		cID, pID := uint(communityID), uint(postID)
+		reports, n, err := s.ReportService.FindReports(c.Request.Context(), sm.ReportFilter{
+			CommunityID: &cID,
+			PostID:      &pID,
+			Limit:       query.Limit,
+			Offset:      query.Offset,
+		})
+		if err != nil {
+			log.Printf("ERROR <getPostReports> - finding reports: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":       n,
+			"reports": reports,
+		})
+	}
+}
+
+// POST /communities/:id/reports/:postId/resolve
+func (s *Server) resolveReports() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Action string `json:"action" binding:"required"`
+		}
+
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		postID, err := strconv.ParseUint(c.Param("postId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid post id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		post, err := s.PostService.FindPostByID(c.Request.Context(), uint(postID))
+		if err != nil {
+			if sm.ErrorCode(err) == sm.ENOTFOUND {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <resolveReports> - finding post by id: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		} else if post.CommunityID != uint(communityID) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Post not found in this community",
+			})
+			return
+		}
+
+		if err := s.ReportService.ResolveReports(c.Request.Context(), post.ID, req.Action); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <resolveReports> - resolving reports on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Reports resolved successfully",
+		})
+	}
+}
@@ -1,10 +1,13 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	sm "github.com/maliByatzes/socialmedia"
@@ -38,6 +41,39 @@ func (s *Server) requireAuth() gin.HandlerFunc {
 			return
 		}
 
+		// Tokens of revoked sessions are still valid JWTs, so the row has to
+		// exist as well. Rows found recently are cached for TokenCacheTTL.
+		gen := s.tokens.generation()
+		tk := s.tokens.get(accessToken)
+		if tk == nil {
+			tks, _, err := s.TokenService.FindTokens(c.Request.Context(), sm.TokenFilter{AccessToken: &accessToken})
+			if err != nil {
+				log.Printf("ERROR <requireAuth> - finding token: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Internal Server Error",
+				})
+				c.Abort()
+				return
+			} else if len(tks) == 0 {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": "Unauthorized - Session has been revoked",
+				})
+				c.Abort()
+				return
+			}
+
+			tk = tks[0]
+			s.tokens.set(tk, gen)
+		}
+
+		if tk.UserID != payload.ID {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Unauthorized - Session has been revoked",
+			})
+			c.Abort()
+			return
+		}
+
 		user, err := s.UserService.FindUserByID(c.Request.Context(), payload.ID)
 		if err != nil {
 			if sm.ErrorCode(err) == sm.ENOTFOUND {
@@ -58,7 +94,145 @@ func (s *Server) requireAuth() gin.HandlerFunc {
 
 		ctx := sm.NewContextWithUser(c.Request.Context(), user)
 		c.Request = c.Request.WithContext(ctx)
+		c.Set("token_id", tk.ID)
+
+		// Last use is only tracked to the minute, to spare a write on every
+		// request.
+		if now := time.Now(); now.Sub(tk.LastUsedAt) > time.Minute {
+			if _, err := s.TokenService.UpdateToken(ctx, tk.ID, sm.TokenUpdate{LastUsedAt: &now}); err != nil {
+				log.Printf("ERROR <requireAuth> - updating token last use: %v", err)
+			} else {
+				tk.LastUsedAt = now
+				s.tokens.set(tk, gen)
+			}
+		}
 
 		c.Next()
 	}
 }
+
+// requireAdmin only accepts admin tokens, which are signed with their own key
+// and checked against admin_tokens so that logging out revokes them. User
+// tokens are never accepted here.
+func (s *Server) requireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		accessToken := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if accessToken == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Unauthorized - No access token",
+			})
+			c.Abort()
+			return
+		}
+
+		payload, err := s.AdminTokenMaker.VerifyToken(accessToken)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": fmt.Sprintf("Unauthorized - %v", err),
+			})
+			c.Abort()
+			return
+		}
+
+		_, n, err := s.AdminTokenService.FindAdminTokens(c.Request.Context(), sm.AdminTokenFilter{
+			AdminID:     &payload.ID,
+			AccessToken: &accessToken,
+		})
+		if err != nil {
+			log.Printf("ERROR <requireAdmin> - finding admin tokens: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			c.Abort()
+			return
+		} else if n == 0 {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Unauthorized - Token has been revoked",
+			})
+			c.Abort()
+			return
+		}
+
+		admin, err := s.AdminService.FindAdminByID(c.Request.Context(), payload.ID)
+		if err != nil {
+			if sm.ErrorCode(err) == sm.ENOTFOUND {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				c.Abort()
+				return
+			}
+
+			log.Printf("ERROR <requireAdmin> - finding admin by id: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			c.Abort()
+			return
+		}
+
+		ctx := sm.NewContextWithAdmin(c.Request.Context(), admin)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// requireModerator only lets through users that moderate the community whose
+// id is in the given path param. It must run after requireAuth.
+func (s *Server) requireModerator(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		communityID, err := strconv.ParseUint(c.Param(param), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			c.Abort()
+			return
+		}
+
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not found",
+			})
+			c.Abort()
+			return
+		}
+
+		ok, err := s.isCommunityModerator(c.Request.Context(), uint(communityID), user)
+		if err != nil {
+			log.Printf("ERROR <requireModerator> - finding community members: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			c.Abort()
+			return
+		} else if !ok {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Forbidden - Moderators only",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// isCommunityModerator reports whether user moderates the community.
+func (s *Server) isCommunityModerator(ctx context.Context, communityID uint, user *sm.User) (bool, error) {
+	bTrue := true
+	_, n, err := s.CommunityMemberService.FindCommunityMembers(ctx, sm.CommunityMemberFilter{
+		CommunityID: &communityID,
+		UserID:      &user.ID,
+		IsModerator: &bTrue,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
@@ -5,6 +5,27 @@ import "github.com/gin-gonic/gin"
 func (s *Server) routes() {
 	s.Router.Use(CorsMiddleware())
 
+	// Admin routes use their own tokens and never go through requireAuth.
+	adminRouter := s.Router.Group("/api/v1/admin")
+	{
+		adminRouter.POST("/signin", s.adminSignin())
+
+		adminRouter.Use(s.requireAdmin())
+		{
+			adminRouter.GET("/me", s.getCurrentAdmin())
+			adminRouter.POST("/logout", s.adminLogout())
+			adminRouter.POST("/admins", s.createAdmin())
+			adminRouter.GET("/config", s.getConfig())
+			adminRouter.PATCH("/config", s.updateConfig())
+			adminRouter.GET("/logs", s.getLogs())
+			adminRouter.DELETE("/users/:id/sessions", s.revokeUserSessions())
+
+			adminRouter.POST("/communities", s.createCommunity())
+			adminRouter.PUT("/communities/:id/moderators/:userId", s.setCommunityModerator(true))
+			adminRouter.DELETE("/communities/:id/moderators/:userId", s.setCommunityModerator(false))
+		}
+	}
+
 	apiRouter := s.Router.Group("/api/v1")
 	{
 		apiRouter.GET("/healthchecker", healthCheck())
@@ -17,12 +38,72 @@ func (s *Server) routes() {
 			s.signin()(c)
 			s.sendLoginVerificationEmail()(c)
 		}))
+		apiRouter.POST("/users/refresh", s.refreshToken())
+		apiRouter.POST("/auth/verify", s.verifyEmail())
+		apiRouter.POST("/auth/verify/resend", gin.HandlerFunc(func(c *gin.Context) {
+			s.resendVerificationEmail()(c)
+			s.sendVerificationEmail()(c)
+		}))
+		apiRouter.POST("/auth/verify-login", s.verifyLogin())
+		apiRouter.POST("/auth/block-device", s.blockDevice())
 
 		apiRouter.Use(s.requireAuth())
 		{
 			apiRouter.GET("/users/me", s.getCurrentUser())
-			apiRouter.PATCH("/users/update", s.updateUserInfo())
+			apiRouter.GET("/users/me/saved-posts", s.getSavedPosts())
+			apiRouter.GET("/users/me/pending-posts", s.getMyPendingPosts())
+			apiRouter.GET("/users/moderator/profile", s.getModeratorProfile())
+			apiRouter.DELETE("/users/me", s.deleteCurrentUser())
+			apiRouter.PATCH("/users/me/password", s.changePassword())
+			apiRouter.GET("/users/me/sessions", s.getSessions())
+			apiRouter.DELETE("/users/me/sessions", s.deleteOtherSessions())
+			apiRouter.DELETE("/users/me/sessions/:id", s.deleteSession())
+			apiRouter.PATCH("/users/update", gin.HandlerFunc(func(c *gin.Context) {
+				s.updateUserInfo()(c)
+				s.sendVerificationEmail()(c)
+			}))
 			apiRouter.POST("/users/logout", s.logout())
+			apiRouter.POST("/users/:id/follow", s.followUser())
+			apiRouter.DELETE("/users/:id/follow", s.unfollowUser())
+			apiRouter.GET("/users/:id/followers", s.getFollowers())
+			apiRouter.GET("/users/:id/following", s.getFollowing())
+
+			apiRouter.POST("/posts", s.createPost())
+			apiRouter.GET("/posts", s.getPosts())
+			apiRouter.GET("/posts/:id", s.getPost())
+			apiRouter.PATCH("/posts/:id", s.updatePost())
+			apiRouter.DELETE("/posts/:id", s.deletePost())
+			apiRouter.GET("/posts/:id/likes", s.getPostLikes())
+			apiRouter.POST("/posts/:id/like", s.likePost())
+			apiRouter.DELETE("/posts/:id/like", s.unlikePost())
+			apiRouter.POST("/posts/:id/save", s.savePost())
+			apiRouter.DELETE("/posts/:id/save", s.unsavePost())
+			apiRouter.POST("/posts/:id/report", s.reportPost())
+			apiRouter.GET("/posts/:id/comments", s.getComments())
+			apiRouter.POST("/posts/:id/comments", s.createComment())
+			apiRouter.PATCH("/comments/:id", s.updateComment())
+			apiRouter.DELETE("/comments/:id", s.deleteComment())
+
+			apiRouter.GET("/communities", s.getCommunities())
+			apiRouter.GET("/communities/:id", s.getCommunity())
+			apiRouter.PATCH("/communities/:id", s.requireModerator("id"), s.updateCommunity())
+			apiRouter.POST("/communities/:id/join", s.joinCommunity())
+			apiRouter.POST("/communities/:id/leave", s.leaveCommunity())
+			apiRouter.GET("/communities/:id/members", s.getCommunityMembers())
+			apiRouter.GET("/communities/:id/moderators", s.getCommunityModerators())
+			apiRouter.GET("/communities/:id/bans", s.requireModerator("id"), s.getCommunityBans())
+			apiRouter.POST("/communities/:id/bans", s.requireModerator("id"), s.banUser())
+			apiRouter.DELETE("/communities/:id/bans/:userId", s.requireModerator("id"), s.unbanUser())
+			apiRouter.GET("/communities/:id/rules", s.getRules())
+			apiRouter.POST("/communities/:id/rules", s.requireModerator("id"), s.createRule())
+			apiRouter.PATCH("/communities/:id/rules/:ruleId", s.requireModerator("id"), s.updateRule())
+			apiRouter.DELETE("/communities/:id/rules/:ruleId", s.requireModerator("id"), s.deleteRule())
+			apiRouter.GET("/communities/:id/reports", s.requireModerator("id"), s.getReportQueue())
+			apiRouter.GET("/communities/:id/reports/:postId", s.requireModerator("id"), s.getPostReports())
+			apiRouter.POST("/communities/:id/reports/:postId/resolve", s.requireModerator("id"), s.resolveReports())
+			apiRouter.GET("/communities/:id/pending-posts", s.requireModerator("id"), s.getPendingPosts())
+			apiRouter.POST("/communities/:id/pending-posts/:postId/approve", s.requireModerator("id"), s.approvePendingPost())
+			apiRouter.POST("/communities/:id/pending-posts/:postId/reject", s.requireModerator("id"), s.rejectPendingPost())
 		}
 	}
 }
@@ -0,0 +1,244 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// GET /communities/:id/rules
+func (s *Server) getRules() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		id := uint(communityID)
+		rules, n, err := s.RuleService.FindRules(c.Request.Context(), sm.RuleFilter{
+			CommunityID: &id,
+		})
+		if err != nil {
+			log.Printf("ERROR <getRules> - finding rules: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":     n,
+			"rules": rules,
+		})
+	}
+}
+
+// POST /communities/:id/rules
+func (s *Server) createRule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Rule struct {
+				Rule        string `json:"rule" binding:"required"`
+				Description string `json:"description"`
+			} `json:"rule" binding:"required"`
+		}
+
+		communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid community id param",
+			})
+			return
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		newRule := sm.Rule{
+			CommunityID: uint(communityID),
+			Rule:        req.Rule.Rule,
+			Description: req.Rule.Description,
+		}
+
+		if err := s.RuleService.CreateRule(c.Request.Context(), &newRule); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <createRule> - creating new rule on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Rule created successfully",
+			"rule":    newRule,
+		})
+	}
+}
+
+// PATCH /communities/:id/rules/:ruleId
+func (s *Server) updateRule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Rule struct {
+				Rule        *string `json:"rule"`
+				Description *string `json:"description"`
+				Position    *int    `json:"position"`
+			} `json:"rule" binding:"required"`
+		}
+
+		rule, ok := s.findCommunityRule(c, "updateRule")
+		if !ok {
+			return
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		updatedRule, err := s.RuleService.UpdateRule(c.Request.Context(), rule.ID, sm.RuleUpdate{
+			Rule:        req.Rule.Rule,
+			Description: req.Rule.Description,
+			Position:    req.Rule.Position,
+		})
+		if err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <updateRule> - updating rule on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Rule updated successfully",
+			"rule":    updatedRule,
+		})
+	}
+}
+
+// DELETE /communities/:id/rules/:ruleId
+func (s *Server) deleteRule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rule, ok := s.findCommunityRule(c, "deleteRule")
+		if !ok {
+			return
+		}
+
+		if err := s.RuleService.DeleteRule(c.Request.Context(), rule.ID); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <deleteRule> - deleting rule from db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Rule deleted successfully",
+		})
+	}
+}
+
+// findCommunityRule loads the rule in the ruleId param and checks it belongs
+// to the community in the id param. It writes the error response and returns
+// false when it cannot.
+func (s *Server) findCommunityRule(c *gin.Context, name string) (*sm.Rule, bool) {
+	communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid community id param",
+		})
+		return nil, false
+	}
+
+	ruleID, err := strconv.ParseUint(c.Param("ruleId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid rule id param",
+		})
+		return nil, false
+	}
+
+	rule, err := s.RuleService.FindRuleByID(c.Request.Context(), uint(ruleID))
+	if err != nil {
+		if sm.ErrorCode(err) == sm.ENOTFOUND {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": sm.ErrorMessage(err),
+			})
+			return nil, false
+		}
+
+		log.Printf("ERROR <%s> - finding rule by id: %v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Internal Server Error",
+		})
+		return nil, false
+	} else if rule.CommunityID != uint(communityID) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Rule not found in this community",
+		})
+		return nil, false
+	}
+
+	return rule, true
+}
@@ -9,16 +9,23 @@ import (
 
 	"github.com/gin-gonic/gin"
 	sm "github.com/maliByatzes/socialmedia"
+	"github.com/maliByatzes/socialmedia/config"
+	"github.com/maliByatzes/socialmedia/moderation"
 	"github.com/maliByatzes/socialmedia/postgres"
 	"github.com/maliByatzes/socialmedia/token"
 )
 
 const Timeout = 5 * time.Second
 
+// TokenCacheTTL is how long requireAuth trusts a tokens row it looked up
+// before checking the table again.
+const TokenCacheTTL = 30 * time.Second
+
 type Server struct {
 	Server                 *http.Server
 	Router                 *gin.Engine
 	TokenMaker             token.Maker
+	AdminTokenMaker        token.Maker
 	UserService            sm.UserService
 	EmailService           sm.EmailService
 	ContextService         sm.ContextService
@@ -27,24 +34,57 @@ type Server struct {
 	TokenService           sm.TokenService
 	RelationshipService    sm.RelationshipService
 	PostService            sm.PostService
+	PostLikeService        sm.PostLikeService
+	CommentService         sm.CommentService
+	SavedPostService       sm.SavedPostService
+	CommunityService       sm.CommunityService
+	CommunityMemberService sm.CommunityMemberService
+	CBUService             sm.CBUService
+	RuleService            sm.RuleService
+	ReportService          sm.ReportService
+	PendingPostService     sm.PendingPostService
+	ConfigService          sm.ConfigService
+	ContentModerator       sm.ContentModerator
+	AdminService           sm.AdminService
+	AdminTokenService      sm.AdminTokenService
+	LogService             sm.LogService
+
+	// LogRetention is how long logs are kept. Zero keeps them forever.
+	LogRetention time.Duration
+
+	cfg    config.Config
+	tokens *tokenCache
+
+	ctx    context.Context
+	cancel func()
 }
 
-func NewServer(db *postgres.DB, secretKey string) (*Server, error) {
+func NewServer(db *postgres.DB, cfg config.Config) (*Server, error) {
 	s := Server{
 		Server: &http.Server{
 			WriteTimeout: Timeout,
 			ReadTimeout:  Timeout,
 			IdleTimeout:  Timeout,
 		},
-		Router: gin.Default(),
+		Router:       gin.Default(),
+		cfg:          cfg,
+		LogRetention: time.Duration(cfg.LogRetentionDays) * 24 * time.Hour,
+		tokens:       newTokenCache(TokenCacheTTL),
 	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
 
-	tkMaker, err := token.NewJWTMaker(secretKey)
+	tkMaker, err := newTokenMaker(cfg, cfg.SecretKey)
 	if err != nil {
 		return nil, err
 	}
 	s.TokenMaker = tkMaker
 
+	adminTkMaker, err := newTokenMaker(cfg, cfg.AdminSecretKey)
+	if err != nil {
+		return nil, err
+	}
+	s.AdminTokenMaker = adminTkMaker
+
 	s.routes()
 	s.UserService = postgres.NewUserService(db)
 	s.EmailService = postgres.NewEmailService(db)
@@ -54,22 +94,66 @@ func NewServer(db *postgres.DB, secretKey string) (*Server, error) {
 	s.TokenService = postgres.NewTokenService(db)
 	s.RelationshipService = postgres.NewRelationshipService(db)
 	s.PostService = postgres.NewPostService(db)
+	s.PostLikeService = postgres.NewPostLikeService(db)
+	s.CommentService = postgres.NewCommentService(db)
+	s.SavedPostService = postgres.NewSavedPostService(db)
+	s.CommunityService = postgres.NewCommunityService(db)
+	s.CommunityMemberService = postgres.NewCommunityMemberService(db)
+	s.CBUService = postgres.NewCBUService(db)
+	s.RuleService = postgres.NewRuleService(db)
+	s.ReportService = postgres.NewReportService(db)
+	s.PendingPostService = postgres.NewPendingPostService(db)
+	s.ConfigService = postgres.NewConfigService(db)
+	s.AdminService = postgres.NewAdminService(db)
+	s.AdminTokenService = postgres.NewAdminTokenService(db)
+	s.LogService = postgres.NewLogService(db)
+
+	keywordModerator, err := moderation.NewKeywordModerator(cfg.ModerationKeywords)
+	if err != nil {
+		return nil, err
+	}
+	s.ContentModerator = moderation.NewModerator(s.ConfigService, keywordModerator)
+
 	s.Server.Handler = s.Router
 
 	return &s, nil
 }
 
+// newTokenMaker builds the maker selected by cfg.TokenType from secretKey.
+func newTokenMaker(cfg config.Config, secretKey string) (token.Maker, error) {
+	jwtMaker, err := token.NewJWTMaker(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.TokenType != "paseto" {
+		return jwtMaker, nil
+	}
+
+	if !cfg.JWTFallback {
+		jwtMaker = nil
+	}
+	return token.NewPasetoMaker(secretKey, jwtMaker)
+}
+
 func (s *Server) Run(port string) error {
 	if !strings.HasPrefix(port, ":") {
 		port = ":" + port
 	}
 
 	s.Server.Addr = port
+
+	if s.LogRetention > 0 {
+		go s.purgeLogs(s.ctx, s.LogRetention, time.Hour)
+	}
+
 	log.Printf("🗿 Server is starting on port %s", port)
 	return s.Server.ListenAndServe()
 }
 
 func (s *Server) Close() error {
+	s.cancel()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 	return s.Server.Shutdown(ctx)
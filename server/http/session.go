@@ -0,0 +1,150 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// GET /users/me/sessions
+//
+// Lists the devices the user is signed in on. Sessions whose refresh token
+// has expired are left out, since they can no longer be used.
+func (s *Server) getSessions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not found",
+			})
+			return
+		}
+
+		tks, _, err := s.TokenService.FindTokens(c.Request.Context(), sm.TokenFilter{UserID: &user.ID})
+		if err != nil {
+			log.Printf("ERROR <getSessions> - finding tokens: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		contexts, _, err := s.ContextService.FindContexts(c.Request.Context(), sm.ContextFilter{UserID: &user.ID})
+		if err != nil {
+			log.Printf("ERROR <getSessions> - finding contexts: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		contextsByID := make(map[uint]*sm.Context, len(contexts))
+		for _, context := range contexts {
+			contextsByID[context.ID] = context
+		}
+
+		currentID := c.GetUint("token_id")
+
+		sessions := make([]*sm.Session, 0, len(tks))
+		for _, tk := range tks {
+			if _, err := s.TokenMaker.VerifyToken(tk.RefreshToken); err != nil {
+				continue
+			}
+
+			session := sm.Session{
+				ID:         tk.ID,
+				Current:    tk.ID == currentID,
+				CreatedAt:  tk.CreatedAt,
+				LastUsedAt: tk.LastUsedAt,
+			}
+			if tk.ContextID != nil {
+				if context, ok := contextsByID[*tk.ContextID]; ok {
+					session.IP = context.IP
+					session.Country = context.Country
+					session.City = context.City
+					session.Browser = context.Browser
+					session.OS = context.OS
+					session.Device = context.Device
+				}
+			}
+
+			sessions = append(sessions, &session)
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"n":        len(sessions),
+			"sessions": sessions,
+		})
+	}
+}
+
+// DELETE /users/me/sessions/:id
+func (s *Server) deleteSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessionID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid session id param",
+			})
+			return
+		}
+
+		if err := s.TokenService.DeleteToken(c.Request.Context(), uint(sessionID)); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <deleteSession> - deleting token from db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+		s.tokens.revokeUser(sm.UserIDFromContext(c.Request.Context()))
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Session revoked successfully",
+		})
+	}
+}
+
+// DELETE /users/me/sessions
+//
+// Signs the user out on every device but the one making the request.
+func (s *Server) deleteOtherSessions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		n, err := s.TokenService.DeleteOtherTokens(c.Request.Context(), c.GetUint("token_id"))
+		if err != nil {
+			if sm.ErrorCode(err) == sm.ENOTAUTHORIZED {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <deleteOtherSessions> - deleting tokens from db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+		s.tokens.revokeUser(sm.UserIDFromContext(c.Request.Context()))
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Other sessions revoked successfully",
+			"n":       n,
+		})
+	}
+}
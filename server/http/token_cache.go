@@ -0,0 +1,123 @@
+package http
+
+import (
+	"sync"
+	"time"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+// maxTokenCacheEntries bounds the memory used by the cache.
+const maxTokenCacheEntries = 10000
+
+// tokenCache keeps the tokens rows requireAuth has recently looked up, keyed
+// by access token, so it does not query the tokens table on every request.
+// Entries live for ttl at most, which bounds how long a revocation made by
+// another server instance can go unnoticed. Revocations made by this
+// instance drop the user's entries straight away.
+//
+// A request may load a token row just before it is revoked and cache it
+// just after. To catch that, every revocation bumps a generation, callers
+// read the generation before loading a row, and set refuses rows loaded
+// before the last revocation of their user.
+type tokenCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]tokenCacheEntry
+
+	gen     uint64
+	revoked map[uint]uint64
+
+	// floor is the generation at which revoked was last cleared. Rows loaded
+	// before it are refused for every user.
+	floor uint64
+}
+
+type tokenCacheEntry struct {
+	token     sm.Token
+	expiresAt time.Time
+}
+
+func newTokenCache(ttl time.Duration) *tokenCache {
+	return &tokenCache{
+		ttl:     ttl,
+		entries: make(map[string]tokenCacheEntry),
+		revoked: make(map[uint]uint64),
+	}
+}
+
+// get returns a copy of the cached token, or nil if there is none or it is
+// too old.
+func (c *tokenCache) get(accessToken string) *sm.Token {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[accessToken]
+	if !ok {
+		return nil
+	} else if time.Now().After(entry.expiresAt) {
+		delete(c.entries, accessToken)
+		return nil
+	}
+
+	tk := entry.token
+	return &tk
+}
+
+// generation returns the current revocation generation, to be read before
+// loading a token row that will be passed to set.
+func (c *tokenCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.gen
+}
+
+// set caches tk, which was loaded at generation gen, unless the user's tokens
+// have been revoked since.
+func (c *tokenCache) set(tk *sm.Token, gen uint64) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gen < c.floor || gen < c.revoked[tk.UserID] {
+		return
+	}
+
+	now := time.Now()
+	if len(c.entries) >= maxTokenCacheEntries {
+		for k, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxTokenCacheEntries {
+			clear(c.entries)
+		}
+	}
+
+	c.entries[tk.AccessToken] = tokenCacheEntry{token: *tk, expiresAt: now.Add(c.ttl)}
+}
+
+// revokeUser drops every cached token of the user. It is called whenever
+// tokens of the user are deleted or replaced.
+func (c *tokenCache) revokeUser(userID uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	if len(c.revoked) >= maxTokenCacheEntries {
+		clear(c.revoked)
+		c.floor = c.gen
+	}
+	c.revoked[userID] = c.gen
+
+	for k, entry := range c.entries {
+		if entry.token.UserID == userID {
+			delete(c.entries, k)
+		}
+	}
+}
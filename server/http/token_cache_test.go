@@ -0,0 +1,73 @@
+package http
+
+import (
+	"testing"
+	"time"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+func TestTokenCache(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		c := newTokenCache(time.Minute)
+		c.set(&sm.Token{ID: 1, UserID: 7, AccessToken: "a"}, c.generation())
+
+		if tk := c.get("a"); tk == nil || tk.ID != 1 {
+			t.Fatalf("unexpected token: %#v", tk)
+		} else if tk := c.get("b"); tk != nil {
+			t.Fatalf("unexpected token: %#v", tk)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		c := newTokenCache(time.Millisecond)
+		c.set(&sm.Token{ID: 1, UserID: 7, AccessToken: "a"}, c.generation())
+		time.Sleep(5 * time.Millisecond)
+
+		if tk := c.get("a"); tk != nil {
+			t.Fatalf("expected expired token to be dropped, got %#v", tk)
+		}
+	})
+
+	t.Run("RevokeUser", func(t *testing.T) {
+		c := newTokenCache(time.Minute)
+		c.set(&sm.Token{ID: 1, UserID: 7, AccessToken: "a"}, c.generation())
+		c.set(&sm.Token{ID: 2, UserID: 7, AccessToken: "b"}, c.generation())
+		c.set(&sm.Token{ID: 3, UserID: 8, AccessToken: "c"}, c.generation())
+		c.revokeUser(7)
+
+		if c.get("a") != nil || c.get("b") != nil {
+			t.Fatal("expected tokens of user 7 to be revoked")
+		} else if c.get("c") == nil {
+			t.Fatal("expected token of user 8 to be kept")
+		}
+	})
+
+	t.Run("RevokedWhileLoading", func(t *testing.T) {
+		c := newTokenCache(time.Minute)
+		gen := c.generation()
+		c.revokeUser(7)
+		c.set(&sm.Token{ID: 1, UserID: 7, AccessToken: "a"}, gen)
+		c.set(&sm.Token{ID: 2, UserID: 8, AccessToken: "b"}, gen)
+
+		if c.get("a") != nil {
+			t.Fatal("expected token loaded before the revocation not to be cached")
+		} else if c.get("b") == nil {
+			t.Fatal("expected token of user 8 to be cached")
+		}
+
+		c.set(&sm.Token{ID: 1, UserID: 7, AccessToken: "a"}, c.generation())
+		if c.get("a") == nil {
+			t.Fatal("expected token loaded after the revocation to be cached")
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		c := newTokenCache(0)
+		c.set(&sm.Token{ID: 1, UserID: 7, AccessToken: "a"}, c.generation())
+
+		if tk := c.get("a"); tk != nil {
+			t.Fatalf("unexpected token: %#v", tk)
+		}
+	})
+}
@@ -13,16 +13,16 @@ import (
 )
 
 func (s *Server) addUser() gin.HandlerFunc {
-	var req struct {
-		User struct {
-			Name           string `json:"name" binding:"required,min=3"`
-			Email          string `json:"email" binding:"required,email"`
-			Password       string `json:"password" binding:"required,min=8,max=72"`
-			IsConsentGiven string `json:"is_consent_given" binding:"required"`
-		} `json:"user" binding:"required"`
-	}
-
 	return func(c *gin.Context) {
+		var req struct {
+			User struct {
+				Name           string `json:"name" binding:"required,min=3"`
+				Email          string `json:"email" binding:"required,email"`
+				Password       string `json:"password" binding:"required,min=8,max=72"`
+				IsConsentGiven string `json:"is_consent_given" binding:"required"`
+			} `json:"user" binding:"required"`
+		}
+
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": err.Error(),
@@ -97,14 +97,14 @@ func (s *Server) addUser() gin.HandlerFunc {
 }
 
 func (s *Server) signin() gin.HandlerFunc {
-	var req struct {
-		User struct {
-			Email    string `json:"email" binding:"required,email"`
-			Password string `json:"password" binding:"required"`
-		} `json:"user" binding:"required"`
-	}
-
 	return func(c *gin.Context) {
+		var req struct {
+			User struct {
+				Email    string `json:"email" binding:"required,email"`
+				Password string `json:"password" binding:"required"`
+			} `json:"user" binding:"required"`
+		}
+
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": err.Error(),
@@ -114,9 +114,11 @@ func (s *Server) signin() gin.HandlerFunc {
 
 		user, err := s.UserService.Authenticate(c.Request.Context(), req.User.Email, req.User.Password)
 		if err != nil || user == nil {
-			if sm.ErrorCode(err) == sm.ENOTAUTHORIZED {
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error": sm.ErrorMessage(err),
+			// Unknown emails get the same answer as wrong passwords.
+			if code := sm.ErrorCode(err); code == sm.ENOTAUTHORIZED || code == sm.ENOTFOUND {
+				s.logEvent(c, req.User.Email, sm.LogTypeSignInFailed, sm.LogLevelWarn, "Sign-in failed: invalid credentials")
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": "Invalid credentials",
 				})
 				return
 			}
@@ -128,48 +130,58 @@ func (s *Server) signin() gin.HandlerFunc {
 			return
 		}
 
-		// NOTE: Implement auth context
+		ctx := sm.NewContextWithUser(c.Request.Context(), user)
+		c.Request = c.Request.WithContext(ctx)
 
-		accessToken, _, err := s.TokenMaker.CreateToken(
-			user.ID,
-			user.Name,
-			time.Hour*6,
-		)
-		if err != nil {
-			log.Printf("ERROR <signin> - creating access token: %v", err)
+		preference, err := s.PreferenceService.FindPreferenceByUserID(ctx, user.ID)
+		if err != nil && sm.ErrorCode(err) != sm.ENOTFOUND {
+			log.Printf("ERROR <signin> - finding preference by user id: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Internal Server Error",
 			})
 			return
 		}
 
-		refreshToken, _, err := s.TokenMaker.CreateToken(
-			user.ID,
-			user.Name,
-			time.Hour*168,
-		)
-		if err != nil {
-			log.Printf("ERROR <signin> - creating refresh token: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Internal Server Error",
-			})
-			return
+		if preference != nil && preference.EnabledContextBasedAuth {
+			suspiciousLogin, err := s.verifyContextData(c, user)
+			if err != nil {
+				log.Printf("ERROR <signin> - verifying context data: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Internal Server Error",
+				})
+				return
+			}
+
+			if suspiciousLogin != nil && suspiciousLogin.IsBlocked {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error": "Sign-in from this device has been blocked",
+				})
+				return
+			}
+
+			// sendLoginVerificationEmail picks these up once signin returns.
+			if suspiciousLogin != nil && !suspiciousLogin.IsTrusted {
+				c.Set("email", user.Email)
+				c.Set("name", user.Name)
+				c.Set("suspicious_login", suspiciousLogin)
+
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"message": "Access blocked due to suspicious activity. Verification email was sent to your email address.",
+				})
+				return
+			}
 		}
 
-		if err := s.TokenService.CreateToken(c.Request.Context(), &sm.Token{
-			UserID:       user.ID,
-			RefreshToken: refreshToken,
-			AccessToken:  accessToken,
-		}); err != nil {
-			log.Printf("ERROR <signin> - creating new token on db: %v", err)
+		accessToken, refreshToken, err := s.createUserTokens(c, user)
+		if err != nil {
+			log.Printf("ERROR <signin> - creating tokens: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Internal Server Error",
 			})
 			return
 		}
 
-		c.Set("email", req.User.Email)
-		c.Set("name", user.Name)
+		s.logEvent(c, user.Email, sm.LogTypeSignIn, sm.LogLevelInfo, "Signed in")
 
 		c.JSON(http.StatusOK, gin.H{
 			"access_token":            accessToken,
@@ -180,6 +192,31 @@ func (s *Server) signin() gin.HandlerFunc {
 	}
 }
 
+// createUserTokens creates a new access and refresh token pair for user and
+// stores it as a session of the device making the request.
+func (s *Server) createUserTokens(c *gin.Context, user *sm.User) (accessToken, refreshToken string, err error) {
+	accessToken, _, err = s.TokenMaker.CreateToken(user.ID, user.Name, time.Hour*6)
+	if err != nil {
+		return "", "", fmt.Errorf("creating access token: %w", err)
+	}
+
+	refreshToken, _, err = s.TokenMaker.CreateToken(user.ID, user.Name, time.Hour*168)
+	if err != nil {
+		return "", "", fmt.Errorf("creating refresh token: %w", err)
+	}
+
+	if err := s.TokenService.CreateToken(c.Request.Context(), &sm.Token{
+		UserID:       user.ID,
+		RefreshToken: refreshToken,
+		AccessToken:  accessToken,
+		ContextID:    s.sessionContextID(c, user),
+	}); err != nil {
+		return "", "", fmt.Errorf("creating new token on db: %w", err)
+	}
+
+	return accessToken, refreshToken, nil
+}
+
 func (s *Server) getCurrentUser() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := sm.UserFromContext(c.Request.Context())
@@ -190,10 +227,34 @@ func (s *Server) getCurrentUser() gin.HandlerFunc {
 			return
 		}
 
-		// TODO: Return more info about the user here...
+		_, followers, err := s.RelationshipService.FindRelationships(c.Request.Context(), sm.RelationshipFilter{
+			FollowingID: &user.ID,
+			Limit:       1,
+		})
+		if err != nil {
+			log.Printf("ERROR <getCurrentUser> - counting followers: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		_, following, err := s.RelationshipService.FindRelationships(c.Request.Context(), sm.RelationshipFilter{
+			FollowerID: &user.ID,
+			Limit:      1,
+		})
+		if err != nil {
+			log.Printf("ERROR <getCurrentUser> - counting following: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"user": user,
+			"user":            user,
+			"followers_count": followers,
+			"following_count": following,
 		})
 	}
 }
@@ -235,6 +296,7 @@ func (s *Server) logout() gin.HandlerFunc {
 				})
 				return
 			}
+			s.tokens.revokeUser(tk[0].UserID)
 
 			c.JSON(http.StatusOK, gin.H{
 				"message": "Logout successful",
@@ -243,14 +305,19 @@ func (s *Server) logout() gin.HandlerFunc {
 	}
 }
 
+// POST /users/refresh
+//
+// Exchanges a refresh token for a new access and refresh token pair. Each
+// refresh token works once; presenting one again signs the user out
+// everywhere.
 func (s *Server) refreshToken() gin.HandlerFunc {
-	var req struct {
-		Body struct {
-			RefreshToken string `json:"refresh_token"`
-		} `json:"body" binding:"required"`
-	}
-
 	return func(c *gin.Context) {
+		var req struct {
+			Body struct {
+				RefreshToken string `json:"refresh_token" binding:"required"`
+			} `json:"body" binding:"required"`
+		}
+
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": err.Error(),
@@ -258,25 +325,24 @@ func (s *Server) refreshToken() gin.HandlerFunc {
 			return
 		}
 
-		tks, _, err := s.TokenService.FindTokens(c.Request.Context(), sm.TokenFilter{RefreshToken: &req.Body.RefreshToken})
+		payload, err := s.TokenMaker.VerifyToken(req.Body.RefreshToken)
 		if err != nil {
-			log.Printf("ERROR <refreshToken> - getting token from db: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Internal Server Error",
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": err.Error(),
 			})
 			return
-		} else if len(tks) == 0 {
+		} else if payload.ExpiresAt.Before(time.Now()) {
 			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid refresh token.",
+				"error": "Expired token.",
 			})
 			return
 		}
 
-		user, err := s.UserService.FindUserByID(c.Request.Context(), tks[0].UserID)
+		user, err := s.UserService.FindUserByID(c.Request.Context(), payload.ID)
 		if err != nil {
 			if sm.ErrorCode(err) == sm.ENOTFOUND {
-				c.JSON(http.StatusNotFound, gin.H{
-					"error": sm.ErrorMessage(err),
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": "Invalid refresh token.",
 				})
 				return
 			}
@@ -288,57 +354,111 @@ func (s *Server) refreshToken() gin.HandlerFunc {
 			return
 		}
 
-		payload, err := s.TokenMaker.VerifyToken(tks[0].RefreshToken)
+		accessToken, _, err := s.TokenMaker.CreateToken(user.ID, user.Name, time.Hour*6)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": err.Error(),
+			log.Printf("ERROR <refreshToken> - creating access token: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
 			})
 			return
 		}
 
-		if payload.ExpiresAt.After(time.Now()) {
-			s.TokenService.DeleteToken(c.Request.Context(), tks[0].ID)
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Expired token.",
+		refreshToken, _, err := s.TokenMaker.CreateToken(user.ID, user.Name, time.Hour*168)
+		if err != nil {
+			log.Printf("ERROR <refreshToken> - creating refresh token: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
 			})
 			return
 		}
 
-		accessToken, _, err := s.TokenMaker.CreateToken(
-			user.ID,
-			user.Name,
-			time.Hour*6,
-		)
-		if err != nil {
-			log.Printf("ERROR <refreshToken> - creating access token: %v", err)
+		ctx := sm.NewContextWithUser(c.Request.Context(), user)
+		if _, err := s.TokenService.RotateToken(ctx, req.Body.RefreshToken, sm.TokenUpdate{
+			AccessToken:  &accessToken,
+			RefreshToken: &refreshToken,
+		}); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": "Invalid refresh token.",
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				s.tokens.revokeUser(user.ID)
+				s.logEvent(c, user.Email, sm.LogTypeTokenReuse, sm.LogLevelError, "Rotated refresh token reused, all tokens revoked")
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <refreshToken> - rotating token on db: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Internal Server Error",
 			})
 			return
 		}
+		s.tokens.revokeUser(user.ID)
 
 		c.JSON(http.StatusOK, gin.H{
-			"accessToken":          accessToken,
-			"refreshToken":         tks[0].RefreshToken,
-			"accessTokenUpdatedAt": time.Now().Local(),
+			"access_token":            accessToken,
+			"refresh_token":           refreshToken,
+			"access_token_updated_at": time.Now(),
 		})
 	}
 }
 
 func (s *Server) getModeratorProfile() gin.HandlerFunc {
-	return func(c *gin.Context) {}
-}
+	return func(c *gin.Context) {
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "user not found",
+			})
+			return
+		}
 
-func (s *Server) updateUserInfo() gin.HandlerFunc {
-	var req struct {
-		Body struct {
-			Location  string `json:"location"`
-			Interests string `json:"interests"`
-			Bio       string `json:"bio"`
-		} `json:"body" binding:"required"`
+		communities, n, err := s.CommunityService.FindCommunities(c.Request.Context(), sm.CommunityFilter{
+			ModeratorID: &user.ID,
+		})
+		if err != nil {
+			log.Printf("ERROR <getModeratorProfile> - finding moderated communities: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		} else if n == 0 {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "You are not a moderator of any community",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"user":        user,
+			"n":           n,
+			"communities": communities,
+		})
 	}
+}
 
+// PATCH /users/update
+//
+// Fields left out of the body are not changed. A new email has to be verified
+// again, and sendVerificationEmail sends the code once this handler returns.
+func (s *Server) updateUserInfo() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		var req struct {
+			Body struct {
+				Name      *string `json:"name"`
+				Email     *string `json:"email" binding:"omitempty,email"`
+				Avatar    *string `json:"avatar"`
+				Location  *string `json:"location"`
+				Interests *string `json:"interests"`
+				Bio       *string `json:"bio"`
+			} `json:"body" binding:"required"`
+		}
+
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": err.Error(),
@@ -355,11 +475,37 @@ func (s *Server) updateUserInfo() gin.HandlerFunc {
 		}
 
 		updatedUser, err := s.UserService.UpdateUser(c.Request.Context(), user.ID, sm.UserUpdate{
-			Location:  &req.Body.Location,
-			Interests: &req.Body.Interests,
-			Bio:       &req.Body.Bio,
+			Name:      req.Body.Name,
+			Email:     req.Body.Email,
+			Avatar:    req.Body.Avatar,
+			Location:  req.Body.Location,
+			Interests: req.Body.Interests,
+			Bio:       req.Body.Bio,
 		})
 		if err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ECONFLICT:
+				c.JSON(http.StatusConflict, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
 			log.Printf("ERROR <updateUserInfo> - updating user info to db: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Internal Server Error",
@@ -367,9 +513,107 @@ func (s *Server) updateUserInfo() gin.HandlerFunc {
 			return
 		}
 
+		if updatedUser.Email != user.Email {
+			c.Set("email", updatedUser.Email)
+			c.Set("name", updatedUser.Name)
+		}
+
 		c.JSON(http.StatusOK, gin.H{
 			"message":     "User info updated successfully",
 			"updatedUser": updatedUser,
 		})
 	}
 }
+
+// PATCH /users/me/password
+//
+// Changes the password and signs the user out on every other device.
+func (s *Server) changePassword() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			CurrentPassword string `json:"current_password" binding:"required"`
+			NewPassword     string `json:"new_password" binding:"required,min=8,max=72"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "user not found",
+			})
+			return
+		}
+
+		if err := s.UserService.ChangePassword(c.Request.Context(), user.ID, req.CurrentPassword, req.NewPassword, c.GetUint("token_id")); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <changePassword> - changing password on db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		s.tokens.revokeUser(user.ID)
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Password changed successfully",
+		})
+	}
+}
+
+// DELETE /users/me
+func (s *Server) deleteCurrentUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := sm.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "user not found",
+			})
+			return
+		}
+
+		if err := s.UserService.DeleteUser(c.Request.Context(), user.ID); err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND:
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			case sm.ENOTAUTHORIZED:
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": sm.ErrorMessage(err),
+				})
+				return
+			}
+
+			log.Printf("ERROR <deleteCurrentUser> - deleting user from db: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+		s.tokens.revokeUser(user.ID)
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "User deleted successfully",
+		})
+	}
+}
@@ -4,22 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"math/rand/v2"
+	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	sm "github.com/maliByatzes/socialmedia"
-	"github.com/maliByatzes/socialmedia/config"
 	"github.com/maliByatzes/socialmedia/mail"
+	"github.com/maliByatzes/socialmedia/utils"
 )
 
 func (s *Server) sendVerificationEmail() gin.HandlerFunc {
-	cfg, err := config.NewConfig()
-	if err != nil {
-		log.Fatal(err)
-	}
-
 	return func(c *gin.Context) {
 		c.Next()
 
@@ -29,6 +24,11 @@ func (s *Server) sendVerificationEmail() gin.HandlerFunc {
 		}
 		nameAny, _ := c.Get("name")
 
+		// resendVerificationEmail reserves the verification before it
+		// responds, so only the email is left to send.
+		codeAny, _ := c.Get("verification_code")
+		reservedCode, reserved := codeAny.(string)
+
 		bgCtx := context.Background()
 
 		go func() {
@@ -36,11 +36,18 @@ func (s *Server) sendVerificationEmail() gin.HandlerFunc {
 			email := fmt.Sprintf("%v", emailAny)
 			name := fmt.Sprintf("%v", nameAny)
 
-			verificationCode := rand.IntN(90000) + 10000
-			verificationLink := fmt.Sprintf("%s/auth/verify?code=%d&email=%s",
-				cfg.ClientURL, verificationCode, email)
+			verificationCode := reservedCode
+			if !reserved {
+				var err error
+				if verificationCode, err = utils.RandomCode(verificationCodeSize); err != nil {
+					log.Printf("ERROR <sendVerificationEmail> - %v", err)
+					return
+				}
+			}
+			verificationLink := fmt.Sprintf("%s/auth/verify?code=%s&email=%s",
+				s.cfg.ClientURL, verificationCode, email)
 
-			sender := mail.NewGmailSender("SocialMedia", cfg.Email, cfg.EmailPassword)
+			sender := mail.NewGmailSender("SocialMedia", s.cfg.Email, s.cfg.EmailPassword)
 			content := verifyEmailHTML(string(name), verificationLink, verificationCode)
 			if err := sender.SendEmail("Verify your email address", content, []string{email}, nil, nil); err != nil {
 				log.Printf("ERROR <sendVerificationEmail> - sending email to user: %v", err)
@@ -48,12 +55,14 @@ func (s *Server) sendVerificationEmail() gin.HandlerFunc {
 					"error": "Internal Server Error",
 				})*/
 				return
+			} else if reserved {
+				return
 			}
 
 			newEmailVerification := sm.Email{
 				Email:            email,
-				VerificationCode: fmt.Sprintf("%d", verificationCode),
-				For:              "signup",
+				VerificationCode: verificationCode,
+				For:              sm.EmailForSignup,
 			}
 
 			if err := s.EmailService.CreateEmailVerification(bgCtx, &newEmailVerification); err != nil {
@@ -72,11 +81,6 @@ func (s *Server) sendVerificationEmail() gin.HandlerFunc {
 }
 
 func (s *Server) sendLoginVerificationEmail() gin.HandlerFunc {
-	cfg, err := config.NewConfig()
-	if err != nil {
-		log.Fatal(err)
-	}
-
 	return func(c *gin.Context) {
 		c.Next()
 
@@ -86,21 +90,32 @@ func (s *Server) sendLoginVerificationEmail() gin.HandlerFunc {
 		}
 		nameAny, _ := c.Get("name")
 
-		bgCtx := c.Copy()
+		slAny, exists := c.Get("suspicious_login")
+		if !exists {
+			return
+		}
+		suspiciousLogin := slAny.(*sm.SuspiciousLogin)
+
+		bgCtx := context.Background()
 
 		go func() {
 
 			email := fmt.Sprintf("%v", emailAny)
 			name := fmt.Sprintf("%v", nameAny)
 
-			// NOTE: implement context data
-			currentContextData := sm.Context{}
+			// The id alone is sequential, so the links also carry a random
+			// code that only this email knows.
+			verificationCode, err := utils.RandomCode(verificationCodeSize)
+			if err != nil {
+				log.Printf("ERROR <sendLoginVerificationEmail> - %v", err)
+				return
+			}
 
-			verificationLink := fmt.Sprintf("%s/verify-login?id=%d&email=%s", cfg.ClientURL, currentContextData.ID, email)
-			blockLink := fmt.Sprintf("%s/block-device?id=%d&email=%s", cfg.ClientURL, currentContextData.ID, email)
+			verificationLink := fmt.Sprintf("%s/verify-login?id=%d&code=%s&email=%s", s.cfg.ClientURL, suspiciousLogin.ID, verificationCode, email)
+			blockLink := fmt.Sprintf("%s/block-device?id=%d&code=%s&email=%s", s.cfg.ClientURL, suspiciousLogin.ID, verificationCode, email)
 
-			sender := mail.NewGmailSender("SocialMedia", cfg.Email, cfg.EmailPassword)
-			content := verifyLoginHTML(name, verificationLink, blockLink, currentContextData)
+			sender := mail.NewGmailSender("SocialMedia", s.cfg.Email, s.cfg.EmailPassword)
+			content := verifyLoginHTML(name, verificationLink, blockLink, suspiciousLogin)
 
 			if err := sender.SendEmail("Action Required: Verify Recent Login", content, []string{email}, nil, nil); err != nil {
 				log.Printf("ERROR <sendLoginVerificationEmail> - sending email to user: %v", err)
@@ -112,8 +127,8 @@ func (s *Server) sendLoginVerificationEmail() gin.HandlerFunc {
 
 			newEmailVerification := sm.Email{
 				Email:            email,
-				VerificationCode: strconv.Itoa(int(currentContextData.ID)),
-				For:              "login",
+				VerificationCode: loginVerificationCode(suspiciousLogin.ID, verificationCode),
+				For:              sm.EmailForLogin,
 			}
 
 			if err := s.EmailService.CreateEmailVerification(bgCtx, &newEmailVerification); err != nil {
@@ -131,7 +146,148 @@ func (s *Server) sendLoginVerificationEmail() gin.HandlerFunc {
 	}
 }
 
-func verifyEmailHTML(name, verificationLink string, verificationCode int) string {
+// verificationCodeSize is the number of random bytes in a verification
+// code, enough that codes can't be guessed.
+const verificationCodeSize = 32
+
+// loginVerificationCode is the code stored for a login verification email.
+// It includes the suspicious login id, so the code only works for the login
+// it was sent for.
+func loginVerificationCode(id uint, code string) string {
+	return fmt.Sprintf("%d:%s", id, code)
+}
+
+// verificationResendCooldown is how long a user has to wait before another
+// signup verification email is sent.
+const verificationResendCooldown = time.Minute
+
+// POST /auth/verify
+//
+// Verifies the user's email with the code from the signup verification email
+// and records the device used as the primary one.
+func (s *Server) verifyEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Code  string `json:"code" binding:"required"`
+			Email string `json:"email" binding:"required,email"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		user, err := s.UserService.VerifyUserEmail(c.Request.Context(), req.Email, req.Code)
+		if err != nil {
+			switch sm.ErrorCode(err) {
+			case sm.ENOTFOUND, sm.EINVALID:
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": "Invalid or expired verification code",
+				})
+				return
+			}
+
+			log.Printf("ERROR <verifyEmail> - verifying user email: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		// The email is verified at this point, so failing to record the
+		// device is only logged.
+		ctx := sm.NewContextWithUser(c.Request.Context(), user)
+		if currentContextData, err := s.currentContextData(c); err != nil {
+			log.Printf("ERROR <verifyEmail> - getting current context data: %v", err)
+		} else if err := s.savePrimaryContext(ctx, user, currentContextData); err != nil {
+			log.Printf("ERROR <verifyEmail> - saving primary context: %v", err)
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Email verified successfully",
+			"user":    user,
+		})
+	}
+}
+
+// POST /auth/verify/resend
+//
+// Sends a new signup verification email, at most once per
+// verificationResendCooldown. The verification is reserved here and
+// sendVerificationEmail sends it once this handler returns. Unknown and
+// already verified emails get the same response, so this can't be used to
+// find out which emails have accounts.
+func (s *Server) resendVerificationEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Email string `json:"email" binding:"required,email"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		users, _, err := s.UserService.FindUsers(c.Request.Context(), sm.UserFilter{Email: &req.Email})
+		if err != nil {
+			log.Printf("ERROR <resendVerificationEmail> - finding user by email: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		} else if len(users) == 0 || users[0].IsEmailVerified {
+			c.JSON(http.StatusOK, gin.H{
+				"message": "Verification email sent",
+			})
+			return
+		}
+
+		verificationCode, err := utils.RandomCode(verificationCodeSize)
+		if err != nil {
+			log.Printf("ERROR <resendVerificationEmail> - %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		recent, err := s.EmailService.ReserveEmailVerification(c.Request.Context(), &sm.Email{
+			Email:            users[0].Email,
+			VerificationCode: verificationCode,
+			For:              sm.EmailForSignup,
+		}, verificationResendCooldown)
+		if err != nil {
+			if sm.ErrorCode(err) == sm.ECONFLICT {
+				wait := time.Until(recent.CreatedAt.Add(verificationResendCooldown))
+				c.Header("Retry-After", strconv.Itoa(max(int(wait.Seconds()), 0)+1))
+				c.JSON(http.StatusTooManyRequests, gin.H{
+					"error": "Please wait before requesting another verification email",
+				})
+				return
+			}
+
+			log.Printf("ERROR <resendVerificationEmail> - reserving email verification: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.Set("email", users[0].Email)
+		c.Set("name", users[0].Name)
+		c.Set("verification_code", verificationCode)
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Verification email sent",
+		})
+	}
+}
+
+func verifyEmailHTML(name, verificationLink, verificationCode string) string {
 	return fmt.Sprintf(`
   <div style="max-width: 600px; margin: auto; background-color: #f4f4f4; padding: 20px; border-radius: 10px; box-shadow: 0 2px 4px rgb(104, 182, 255);">
     <div style="background-color: #ffffff; padding: 20px; border-radius: 10px;">
@@ -143,13 +299,13 @@ func verifyEmailHTML(name, verificationLink string, verificationCode int) string
       </div>
       <p style="font-size: 14px; margin-bottom: 20px; text-align: center; color: #4b5563;">Please note that the device you are using for this verification process will be set as your primary device.</p>
       <p style="font-size: 14px; margin-bottom: 20px; text-align: center; color: #6b7280;">The link will expire in 30 minutes.</p>
-      <p style="font-size: 16px; margin-bottom: 15px; text-align: center; color: #3b82f6; font-weight: bold;">Your verification code is: <span style="color: #000000;">%d</span></p>
+      <p style="font-size: 16px; margin-bottom: 15px; text-align: center; color: #3b82f6; font-weight: bold;">Your verification code is: <span style="color: #000000;">%s</span></p>
       <p style="font-size: 14px; margin-bottom: 20px; text-align: center; color: #4b5563;">If you did not create an account, please ignore this email.</p>
     </div>
   </div>`, name, verificationLink, verificationCode)
 }
 
-func verifyLoginHTML(name, verificationLink, blockLink string, currentContextData sm.Context) string {
+func verifyLoginHTML(name, verificationLink, blockLink string, currentContextData *sm.SuspiciousLogin) string {
 	return fmt.Sprintf(`
 	<div style="background-color: #F4F4F4; padding: 20px;">
       <div style="background-color: #fff; padding: 20px; border-radius: 10px;">
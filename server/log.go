@@ -0,0 +1,55 @@
+package socialmedia
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+)
+
+// Types of the security and auth events that are logged.
+const (
+	LogTypeSignIn            = "sign_in"
+	LogTypeSignInFailed      = "sign_in_failed"
+	LogTypeAdminSignIn       = "admin_sign_in"
+	LogTypeAdminSignInFailed = "admin_sign_in_failed"
+	LogTypeContextMismatch   = "context_mismatch"
+	LogTypeBlock             = "block"
+	LogTypeTokenReuse        = "token_reuse"
+)
+
+// Log is an application event persisted to the logs table. Context holds
+// details of the request that caused it, such as the IP address.
+type Log struct {
+	ID        uint      `json:"id"`
+	Email     string    `json:"email"`
+	Context   string    `json:"context"`
+	Message   string    `json:"message"`
+	Type      string    `json:"type"`
+	Level     string    `json:"level"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LogService interface {
+	FindLogs(ctx context.Context, filter LogFilter) ([]*Log, int, error)
+	CreateLog(ctx context.Context, log *Log) error
+
+	// DeleteLogsBefore purges logs created before t and returns how many
+	// were removed.
+	DeleteLogsBefore(ctx context.Context, t time.Time) (int, error)
+}
+
+type LogFilter struct {
+	Email *string    `json:"email"`
+	Type  *string    `json:"type"`
+	Level *string    `json:"level"`
+	From  *time.Time `json:"from"`
+	To    *time.Time `json:"to"`
+
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
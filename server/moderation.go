@@ -0,0 +1,15 @@
+package socialmedia
+
+import "context"
+
+// ModerationResult is the verdict of a ContentModerator.
+type ModerationResult struct {
+	Flagged bool     `json:"flagged"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// ContentModerator checks user content before it is published. An error
+// means the content could not be checked, not that it was flagged.
+type ContentModerator interface {
+	Moderate(ctx context.Context, content string) (*ModerationResult, error)
+}
@@ -0,0 +1,72 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+var _ sm.ContentModerator = (*HTTPModerator)(nil)
+
+// DefaultTimeout bounds requests to the provider when no timeout is
+// configured, so a slow provider can't hold up posting indefinitely.
+const DefaultTimeout = 5 * time.Second
+
+// HTTPModerator asks a remote provider to classify content. The content is
+// POSTed as {"content": "..."} and the provider answers with a JSON encoded
+// sm.ModerationResult. A Timeout of zero or less means DefaultTimeout.
+type HTTPModerator struct {
+	URL     string
+	Timeout time.Duration
+	Client  *http.Client
+}
+
+func NewHTTPModerator(url string, timeout time.Duration) *HTTPModerator {
+	return &HTTPModerator{
+		URL:     url,
+		Timeout: timeout,
+		Client:  http.DefaultClient,
+	}
+}
+
+func (m *HTTPModerator) Moderate(ctx context.Context, content string) (*sm.ModerationResult, error) {
+	timeout := m.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	body, err := json.Marshal(map[string]string{"content": content})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("moderation provider returned status %d", resp.StatusCode)
+	}
+
+	var result sm.ModerationResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding moderation provider response: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,56 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+var _ sm.ContentModerator = (*KeywordModerator)(nil)
+
+// KeywordModerator flags content matching any of its keywords. It runs
+// locally and never fails.
+type KeywordModerator struct {
+	keywords []string
+	patterns []*regexp.Regexp
+}
+
+// NewKeywordModerator compiles the keywords into patterns. Plain keywords
+// match whole words regardless of case, while keywords starting with "re:"
+// are used as regular expressions.
+func NewKeywordModerator(keywords []string) (*KeywordModerator, error) {
+	m := &KeywordModerator{}
+
+	for _, keyword := range keywords {
+		expr := `(?i)\b` + regexp.QuoteMeta(keyword) + `\b`
+		if v, ok := strings.CutPrefix(keyword, "re:"); ok {
+			expr = v
+		}
+
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid moderation keyword %q: %w", keyword, err)
+		}
+
+		m.keywords = append(m.keywords, keyword)
+		m.patterns = append(m.patterns, pattern)
+	}
+
+	return m, nil
+}
+
+func (m *KeywordModerator) Moderate(ctx context.Context, content string) (*sm.ModerationResult, error) {
+	result := &sm.ModerationResult{}
+
+	for i, pattern := range m.patterns {
+		if pattern.MatchString(content) {
+			result.Flagged = true
+			result.Reasons = append(result.Reasons, fmt.Sprintf("matched %q", m.keywords[i]))
+		}
+	}
+
+	return result, nil
+}
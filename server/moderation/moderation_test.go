@@ -0,0 +1,124 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sm "github.com/maliByatzes/socialmedia"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type configService struct {
+	config sm.Config
+}
+
+func (s *configService) FindConfig(ctx context.Context) (*sm.Config, error) {
+	return &s.config, nil
+}
+
+func (s *configService) UpdateConfig(ctx context.Context, upd sm.ConfigUpdate) (*sm.Config, error) {
+	panic("not implemented")
+}
+
+func newStubProvider(t *testing.T, delay time.Duration) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Content string `json:"content"`
+		}
+		// FailNow can't be called off the test goroutine, so failures here
+		// are reported with assert.
+		if err := json.NewDecoder(r.Body).Decode(&req); !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+
+		result := sm.ModerationResult{}
+		if req.Content == "spam" {
+			result = sm.ModerationResult{Flagged: true, Reasons: []string{"SPAM"}}
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(result))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestKeywordModerator(t *testing.T) {
+	m, err := NewKeywordModerator([]string{"badword", `re:b[a@]d+`})
+	require.NoError(t, err)
+
+	result, err := m.Moderate(context.Background(), "This has a BadWord in it")
+	require.NoError(t, err)
+	assert.True(t, result.Flagged)
+
+	result, err = m.Moderate(context.Background(), "b@ddd")
+	require.NoError(t, err)
+	assert.True(t, result.Flagged)
+
+	result, err = m.Moderate(context.Background(), "nothing to see here")
+	require.NoError(t, err)
+	assert.False(t, result.Flagged)
+
+	_, err = NewKeywordModerator([]string{"re:("})
+	assert.Error(t, err)
+}
+
+func TestHTTPModerator(t *testing.T) {
+	srv := newStubProvider(t, 0)
+	m := NewHTTPModerator(srv.URL, time.Second)
+
+	result, err := m.Moderate(context.Background(), "spam")
+	require.NoError(t, err)
+	assert.True(t, result.Flagged)
+	assert.Equal(t, []string{"SPAM"}, result.Reasons)
+
+	result, err = m.Moderate(context.Background(), "hello")
+	require.NoError(t, err)
+	assert.False(t, result.Flagged)
+}
+
+func TestHTTPModerator_Timeout(t *testing.T) {
+	srv := newStubProvider(t, time.Second)
+	m := NewHTTPModerator(srv.URL, 50*time.Millisecond)
+
+	_, err := m.Moderate(context.Background(), "hello")
+	assert.Error(t, err)
+}
+
+func TestModerator(t *testing.T) {
+	srv := newStubProvider(t, 0)
+	local, err := NewKeywordModerator([]string{"badword"})
+	require.NoError(t, err)
+
+	configs := &configService{}
+	m := NewModerator(configs, local)
+
+	// The remote provider is only used once it is turned on.
+	result, err := m.Moderate(context.Background(), "spam")
+	require.NoError(t, err)
+	assert.False(t, result.Flagged)
+
+	configs.config = sm.Config{
+		UsePerspectiveAPI:                true,
+		CategoryFilteringServiceProvider: srv.URL,
+		CategoryFilteringRequestTimeout:  1000,
+	}
+
+	result, err = m.Moderate(context.Background(), "spam")
+	require.NoError(t, err)
+	assert.True(t, result.Flagged)
+
+	result, err = m.Moderate(context.Background(), "badword")
+	require.NoError(t, err)
+	assert.True(t, result.Flagged)
+}
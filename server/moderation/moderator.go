@@ -0,0 +1,51 @@
+package moderation
+
+import (
+	"context"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+var _ sm.ContentModerator = (*Moderator)(nil)
+
+// Moderator runs the local keyword check and then, when the configs table
+// enables it, the remote provider. The config is read on every call so
+// changes take effect without a restart.
+type Moderator struct {
+	ConfigService sm.ConfigService
+	Local         sm.ContentModerator
+
+	// NewRemote builds the remote provider from the config. It defaults to
+	// an HTTPModerator.
+	NewRemote func(cfg *sm.Config) sm.ContentModerator
+}
+
+func NewModerator(configService sm.ConfigService, local sm.ContentModerator) *Moderator {
+	return &Moderator{
+		ConfigService: configService,
+		Local:         local,
+		NewRemote: func(cfg *sm.Config) sm.ContentModerator {
+			return NewHTTPModerator(cfg.CategoryFilteringServiceProvider, cfg.RequestTimeout())
+		},
+	}
+}
+
+func (m *Moderator) Moderate(ctx context.Context, content string) (*sm.ModerationResult, error) {
+	if content == "" {
+		return &sm.ModerationResult{}, nil
+	}
+
+	result, err := m.Local.Moderate(ctx, content)
+	if err != nil || result.Flagged {
+		return result, err
+	}
+
+	cfg, err := m.ConfigService.FindConfig(ctx)
+	if err != nil {
+		return nil, err
+	} else if !cfg.UsePerspectiveAPI || cfg.CategoryFilteringServiceProvider == "" {
+		return result, nil
+	}
+
+	return m.NewRemote(cfg).Moderate(ctx, content)
+}
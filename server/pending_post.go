@@ -0,0 +1,55 @@
+package socialmedia
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	PendingPostStatusPending  = "pending"
+	PendingPostStatusApproved = "approved"
+	PendingPostStatusRejected = "rejected"
+)
+
+// PendingPost is a post waiting for a moderator's approval before it is
+// published in a community that requires it.
+type PendingPost struct {
+	ID          uint      `json:"id"`
+	Content     string    `json:"content"`
+	FileURL     string    `json:"file_url"`
+	CommunityID uint      `json:"community_id"`
+	UserID      uint      `json:"user_id"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (p *PendingPost) Validate() error {
+	if p.CommunityID == 0 {
+		return Errorf(EINVALID, "CommunityID is required.")
+	}
+
+	if p.Content == "" && p.FileURL == "" {
+		return Errorf(EINVALID, "Content or FileURL is required.")
+	}
+
+	return nil
+}
+
+type PendingPostService interface {
+	FindPendingPostByID(ctx context.Context, id uint) (*PendingPost, error)
+	FindPendingPosts(ctx context.Context, filter PendingPostFilter) ([]*PendingPost, int, error)
+	CreatePendingPost(ctx context.Context, post *PendingPost) error
+	ApprovePendingPost(ctx context.Context, id uint) (*Post, error)
+	RejectPendingPost(ctx context.Context, id uint) (*PendingPost, error)
+}
+
+type PendingPostFilter struct {
+	ID          *uint   `json:"id"`
+	CommunityID *uint   `json:"community_id"`
+	UserID      *uint   `json:"user_id"`
+	Status      *string `json:"status"`
+
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
@@ -13,6 +13,22 @@ type Post struct {
 	UserID      uint      `json:"user_id"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	LikeCount    int  `json:"like_count"`
+	LikedByMe    bool `json:"liked_by_me"`
+	CommentCount int  `json:"comment_count"`
+}
+
+func (p *Post) Validate() error {
+	if p.CommunityID == 0 {
+		return Errorf(EINVALID, "CommunityID is required.")
+	}
+
+	if p.Content == "" && p.FileURL == "" {
+		return Errorf(EINVALID, "Content or FileURL is required.")
+	}
+
+	return nil
 }
 
 type PostService interface {
@@ -9,12 +9,16 @@ type PostLike struct {
 	PostID    uint      `json:"post_id"`
 	UserID    uint      `json:"user_id"`
 	CreatedAt time.Time `json:"created_at"`
+
+	// Only set when the likes are found with Fill.
+	User *User `json:"user,omitempty"`
+	Post *Post `json:"post,omitempty"`
 }
 
 type PostLikeService interface {
 	CreatePostLike(ctx context.Context, postLike *PostLike) error
 	FindPostLikes(ctx context.Context, filter PostLikeFilter) ([]*PostLike, int, error)
-	DeletePostLike(ctx context.Context, id uint) error
+	DeletePostLike(ctx context.Context, postID, userID uint) error
 }
 
 type PostLikeFilter struct {
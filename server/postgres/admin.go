@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+var _ sm.AdminService = (*AdminService)(nil)
+
+type AdminService struct {
+	db *DB
+}
+
+func NewAdminService(db *DB) *AdminService {
+	return &AdminService{db: db}
+}
+
+func (s *AdminService) FindAdminByID(ctx context.Context, id uint) (*sm.Admin, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	admin, err := findAdminByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return admin, nil
+}
+
+func (s *AdminService) FindAdmins(ctx context.Context, filter sm.AdminFilter) ([]*sm.Admin, int, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	return findAdmins(ctx, tx, filter)
+}
+
+func (s *AdminService) Authenticate(ctx context.Context, username, password string) (*sm.Admin, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	a, _, err := findAdmins(ctx, tx, sm.AdminFilter{Username: &username})
+	if err != nil {
+		return nil, err
+	} else if len(a) == 0 {
+		return nil, &sm.Error{Code: sm.ENOTAUTHORIZED, Message: "Invalid credentials"}
+	}
+
+	if err := a[0].VerifyPassword(password); err != nil {
+		return nil, &sm.Error{Code: sm.ENOTAUTHORIZED, Message: "Invalid credentials"}
+	}
+
+	return a[0], nil
+}
+
+func (s *AdminService) CreateAdmin(ctx context.Context, admin *sm.Admin) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := createAdmin(ctx, tx, admin); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func findAdminByID(ctx context.Context, tx *Tx, id uint) (*sm.Admin, error) {
+	a, _, err := findAdmins(ctx, tx, sm.AdminFilter{ID: &id})
+	if err != nil {
+		return nil, err
+	} else if len(a) == 0 {
+		return nil, &sm.Error{Code: sm.ENOTFOUND, Message: "Admin not found."}
+	}
+	return a[0], nil
+}
+
+func findAdmins(ctx context.Context, tx *Tx, filter sm.AdminFilter) (_ []*sm.Admin, n int, err error) {
+	where, args := []string{}, []interface{}{}
+	argPos := 1
+
+	if v := filter.ID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.Username; v != nil {
+		where, args = append(where, fmt.Sprintf(`"username" = $%d`, argPos)), append(args, *v)
+	}
+
+	query := `SELECT "id", "username", "password", "created_at", "updated_at", COUNT(*) OVER()
+	FROM "admins"` + formatWhereClause(where) + ` ORDER BY id ASC` + formatLimitOffset(filter.Limit, filter.Offset)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, n, err
+	}
+	defer rows.Close()
+
+	admins := make([]*sm.Admin, 0)
+	for rows.Next() {
+		var admin sm.Admin
+		if err := rows.Scan(
+			&admin.ID,
+			&admin.Username,
+			&admin.Password,
+			(*NullTime)(&admin.CreatedAt),
+			(*NullTime)(&admin.UpdatedAt),
+			&n,
+		); err != nil {
+			return nil, n, err
+		}
+
+		admins = append(admins, &admin)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return admins, n, nil
+}
+
+// createAdmin requires an admin in ctx, except for the very first admin so
+// that one can be set up when the server starts.
+func createAdmin(ctx context.Context, tx *Tx, admin *sm.Admin) error {
+	if sm.AdminFromContext(ctx) == nil {
+		if _, n, err := findAdmins(ctx, tx, sm.AdminFilter{Limit: 1}); err != nil {
+			return err
+		} else if n > 0 {
+			return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to create an admin.")
+		}
+	}
+
+	if err := admin.Validate(); err != nil {
+		return err
+	}
+
+	admin.CreatedAt = tx.now
+	admin.UpdatedAt = admin.CreatedAt
+
+	query := `INSERT INTO "admins" ("username", "password", "created_at", "updated_at")
+	VALUES ($1, $2, $3, $4) RETURNING id`
+	args := []interface{}{
+		admin.Username,
+		admin.Password,
+		(*NullTime)(&admin.CreatedAt),
+		(*NullTime)(&admin.UpdatedAt),
+	}
+
+	if err := tx.QueryRowxContext(ctx, query, args...).Scan(&admin.ID); err != nil {
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "admins_username_key"`:
+			return sm.Errorf(sm.ECONFLICT, "this username already exists.")
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+var _ sm.AdminTokenService = (*AdminTokenService)(nil)
+
+type AdminTokenService struct {
+	db *DB
+}
+
+func NewAdminTokenService(db *DB) *AdminTokenService {
+	return &AdminTokenService{db: db}
+}
+
+func (s *AdminTokenService) FindAdminTokens(ctx context.Context, filter sm.AdminTokenFilter) ([]*sm.AdminToken, int, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	return findAdminTokens(ctx, tx, filter)
+}
+
+func (s *AdminTokenService) CreateAdminToken(ctx context.Context, token *sm.AdminToken) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := createAdminToken(ctx, tx, token); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *AdminTokenService) DeleteAdminToken(ctx context.Context, id uint) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := deleteAdminToken(ctx, tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func findAdminTokens(ctx context.Context, tx *Tx, filter sm.AdminTokenFilter) (_ []*sm.AdminToken, n int, err error) {
+	where, args := []string{}, []interface{}{}
+	argPos := 1
+
+	if v := filter.ID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.AdminID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"admin_id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.AccessToken; v != nil {
+		where, args = append(where, fmt.Sprintf(`"access_token" = $%d`, argPos)), append(args, *v)
+	}
+
+	query := `SELECT "id", "admin_id", "access_token", "created_at", "updated_at", COUNT(*) OVER()
+	FROM "admin_tokens"` + formatWhereClause(where) + ` ORDER BY id ASC` + formatLimitOffset(filter.Limit, filter.Offset)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, n, err
+	}
+	defer rows.Close()
+
+	tokens := make([]*sm.AdminToken, 0)
+	for rows.Next() {
+		var token sm.AdminToken
+		if err := rows.Scan(
+			&token.ID,
+			&token.AdminID,
+			(*NullString)(&token.AccessToken),
+			(*NullTime)(&token.CreatedAt),
+			(*NullTime)(&token.UpdatedAt),
+			&n,
+		); err != nil {
+			return nil, n, err
+		}
+
+		tokens = append(tokens, &token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return tokens, n, nil
+}
+
+func createAdminToken(ctx context.Context, tx *Tx, token *sm.AdminToken) error {
+	token.CreatedAt = tx.now
+	token.UpdatedAt = token.CreatedAt
+
+	query := `INSERT INTO "admin_tokens" ("admin_id", "access_token", "created_at", "updated_at")
+	VALUES ($1, $2, $3, $4) RETURNING id`
+	args := []interface{}{
+		token.AdminID,
+		token.AccessToken,
+		(*NullTime)(&token.CreatedAt),
+		(*NullTime)(&token.UpdatedAt),
+	}
+
+	if err := tx.QueryRowxContext(ctx, query, args...).Scan(&token.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func deleteAdminToken(ctx context.Context, tx *Tx, id uint) error {
+	a, _, err := findAdminTokens(ctx, tx, sm.AdminTokenFilter{ID: &id})
+	if err != nil {
+		return err
+	} else if len(a) == 0 {
+		return &sm.Error{Code: sm.ENOTFOUND, Message: "Token not found."}
+	} else if admin := sm.AdminFromContext(ctx); admin == nil || admin.ID != a[0].AdminID {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to delete this token.")
+	}
+
+	query := `DELETE FROM "admin_tokens" WHERE "id" = $1`
+
+	if _, err := tx.ExecContext(ctx, query, id); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,270 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+var _ sm.CommentService = (*CommentService)(nil)
+
+type CommentService struct {
+	db *DB
+}
+
+func NewCommentService(db *DB) *CommentService {
+	return &CommentService{db: db}
+}
+
+func (s *CommentService) FindCommentByID(ctx context.Context, id uint) (*sm.Comment, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	comment, err := findCommentByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (s *CommentService) FindComments(ctx context.Context, filter sm.CommentFilter) ([]*sm.Comment, int, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	return findComments(ctx, tx, filter)
+}
+
+func (s *CommentService) CreateComment(ctx context.Context, comment *sm.Comment) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := createComment(ctx, tx, comment); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *CommentService) UpdateComment(ctx context.Context, id uint, upd sm.CommentUpdate) (*sm.Comment, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	comment, err := updateComment(ctx, tx, id, upd)
+	if err != nil {
+		return comment, err
+	} else if err := tx.Commit(); err != nil {
+		return comment, err
+	}
+
+	return comment, nil
+}
+
+func (s *CommentService) DeleteComment(ctx context.Context, id uint) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := deleteComment(ctx, tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func findCommentByID(ctx context.Context, tx *Tx, id uint) (*sm.Comment, error) {
+	a, _, err := findComments(ctx, tx, sm.CommentFilter{ID: &id})
+	if err != nil {
+		return nil, err
+	} else if len(a) == 0 {
+		return nil, &sm.Error{Code: sm.ENOTFOUND, Message: "Comment not found."}
+	}
+	return a[0], nil
+}
+
+func findComments(ctx context.Context, tx *Tx, filter sm.CommentFilter) (_ []*sm.Comment, n int, err error) {
+	where, args := []string{}, []interface{}{}
+	argPos := 1
+
+	if v := filter.ID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.PostID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"post_id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.UserID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"user_id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.ParentID; v != nil && *v == 0 {
+		where = append(where, `"parent_id" IS NULL`)
+	} else if v != nil {
+		where, args = append(where, fmt.Sprintf(`"parent_id" = $%d`, argPos)), append(args, *v)
+	}
+
+	query := `SELECT "id", "body", "user_id", "post_id", "parent_id",
+	(SELECT COUNT(*) FROM "comments" AS "replies" WHERE "replies"."parent_id" = "comments"."id"),
+	"created_at", "updated_at", COUNT(*) OVER()
+	FROM "comments"` + formatWhereClause(where) + ` ORDER BY id ASC` + formatLimitOffset(filter.Limit, filter.Offset)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, n, err
+	}
+	defer rows.Close()
+
+	comments := make([]*sm.Comment, 0)
+	for rows.Next() {
+		var comment sm.Comment
+		if err := rows.Scan(
+			&comment.ID,
+			&comment.Body,
+			&comment.UserID,
+			&comment.PostID,
+			&comment.ParentID,
+			&comment.ReplyCount,
+			(*NullTime)(&comment.CreatedAt),
+			(*NullTime)(&comment.UpdatedAt),
+			&n,
+		); err != nil {
+			return nil, n, err
+		}
+
+		comments = append(comments, &comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return comments, n, nil
+}
+
+func createComment(ctx context.Context, tx *Tx, comment *sm.Comment) error {
+	if sm.UserIDFromContext(ctx) != comment.UserID {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to create this comment.")
+	}
+
+	if err := comment.Validate(); err != nil {
+		return err
+	}
+
+	post, err := findPostByID(ctx, tx, comment.PostID)
+	if err != nil {
+		return err
+	}
+
+	if banned, err := isBannedFromCommunity(ctx, tx, post.CommunityID, comment.UserID); err != nil {
+		return err
+	} else if banned {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are banned from this community.")
+	}
+
+	if v := comment.ParentID; v != nil {
+		if parent, err := findCommentByID(ctx, tx, *v); err != nil {
+			return err
+		} else if parent.PostID != comment.PostID {
+			return sm.Errorf(sm.EINVALID, "Parent comment belongs to another post.")
+		}
+	}
+
+	comment.CreatedAt = tx.now
+	comment.UpdatedAt = comment.CreatedAt
+
+	query := `INSERT INTO "comments" ("body", "user_id", "post_id", "parent_id", "created_at", "updated_at")
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	args := []interface{}{
+		comment.Body,
+		comment.UserID,
+		comment.PostID,
+		comment.ParentID,
+		(*NullTime)(&comment.CreatedAt),
+		(*NullTime)(&comment.UpdatedAt),
+	}
+
+	if err := tx.QueryRowxContext(ctx, query, args...).Scan(&comment.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func updateComment(ctx context.Context, tx *Tx, id uint, upd sm.CommentUpdate) (*sm.Comment, error) {
+	comment, err := findCommentByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok, err := canModifyComment(ctx, tx, comment); err != nil {
+		return comment, err
+	} else if !ok {
+		return nil, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to update this comment.")
+	}
+
+	if v := upd.Body; v != nil {
+		comment.Body = *v
+	}
+
+	if err := comment.Validate(); err != nil {
+		return comment, err
+	}
+
+	comment.UpdatedAt = tx.now
+
+	args := []interface{}{
+		comment.Body,
+		(*NullTime)(&comment.UpdatedAt),
+		comment.ID,
+	}
+	query := `UPDATE "comments" SET "body" = $1, "updated_at" = $2 WHERE "id" = $3`
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return comment, err
+	}
+
+	return comment, nil
+}
+
+func deleteComment(ctx context.Context, tx *Tx, id uint) error {
+	comment, err := findCommentByID(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if ok, err := canModifyComment(ctx, tx, comment); err != nil {
+		return err
+	} else if !ok {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to delete this comment.")
+	}
+
+	// Replies are removed along with their parent by the foreign key.
+	query := `DELETE FROM "comments" WHERE "id" = $1`
+
+	if _, err := tx.ExecContext(ctx, query, comment.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// canModifyComment reports whether the user in ctx is the author of the
+// comment or a moderator of the community the post belongs to.
+func canModifyComment(ctx context.Context, tx *Tx, comment *sm.Comment) (bool, error) {
+	userID := sm.UserIDFromContext(ctx)
+	if userID == 0 {
+		return false, nil
+	} else if comment.UserID == userID {
+		return true, nil
+	}
+
+	post, err := findPostByID(ctx, tx, comment.PostID)
+	if err != nil {
+		return false, err
+	}
+
+	return isCommunityModerator(ctx, tx, post.CommunityID, userID)
+}
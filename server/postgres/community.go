@@ -24,6 +24,10 @@ func (s *CommunityService) FindCommunityByID(ctx context.Context, id uint) (*sm.
 		return nil, err
 	}
 
+	if com.Rules, _, err = findRules(ctx, tx, sm.RuleFilter{CommunityID: &com.ID}); err != nil {
+		return nil, err
+	}
+
 	return com, nil
 }
 
@@ -91,9 +95,14 @@ func findCommunities(ctx context.Context, tx *Tx, filter sm.CommunityFilter) (_
 
 	if v := filter.Name; v != nil {
 		where, args = append(where, fmt.Sprintf(`"name" = $%d`, argPos)), append(args, *v)
+		argPos++
 	}
 
-	query := `SELECT "id", "name", "description", "banner", "created_at", "updated_at", COUNT(*) OVER()
+	if v := filter.ModeratorID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"id" IN (SELECT "community_id" FROM "community_members" WHERE "user_id" = $%d AND "is_moderator" = TRUE)`, argPos)), append(args, *v)
+	}
+
+	query := `SELECT "id", "name", "description", "banner", "requires_post_approval", "created_at", "updated_at", COUNT(*) OVER()
 	FROM "communities"` + formatWhereClause(where) + ` ORDER BY id ASC` + formatLimitOffset(filter.Limit, filter.Offset)
 
 	rows, err := tx.QueryContext(ctx, query, args...)
@@ -110,6 +119,7 @@ func findCommunities(ctx context.Context, tx *Tx, filter sm.CommunityFilter) (_
 			&com.Name,
 			(*NullString)(&com.Description),
 			(*NullString)(&com.Banner),
+			&com.RequiresPostApproval,
 			(*NullTime)(&com.CreatedAt),
 			(*NullTime)(&com.UpdatedAt),
 			&n,
@@ -127,22 +137,36 @@ func findCommunities(ctx context.Context, tx *Tx, filter sm.CommunityFilter) (_
 }
 
 func createCommunity(ctx context.Context, tx *Tx, com *sm.Community) error {
+	if sm.AdminFromContext(ctx) == nil {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to create a community.")
+	}
+
+	if err := com.Validate(); err != nil {
+		return err
+	}
+
 	com.CreatedAt = tx.now
 	com.UpdatedAt = com.CreatedAt
 
-	query := `INSERT INTO "communities"("name", "description", "banner", "created_at", "updated_at")
-	VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	query := `INSERT INTO "communities"("name", "description", "banner", "requires_post_approval", "created_at", "updated_at")
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
 	args := []interface{}{
 		com.Name,
 		com.Description,
 		com.Banner,
+		com.RequiresPostApproval,
 		com.CreatedAt,
 		com.UpdatedAt,
 	}
 
 	err := tx.QueryRowxContext(ctx, query, args...).Scan(&com.ID)
 	if err != nil {
-		return err
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "communities_name_key"`:
+			return sm.Errorf(sm.ECONFLICT, "this community name already exists.")
+		default:
+			return err
+		}
 	}
 
 	return nil
@@ -154,6 +178,12 @@ func updateCommunity(ctx context.Context, tx *Tx, id uint, upd sm.CommunityUpdat
 		return com, err
 	}
 
+	if ok, err := canModifyCommunity(ctx, tx, com.ID); err != nil {
+		return com, err
+	} else if !ok {
+		return nil, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to update this community.")
+	}
+
 	if v := upd.Name; v != nil {
 		com.Name = *v
 	}
@@ -166,23 +196,37 @@ func updateCommunity(ctx context.Context, tx *Tx, id uint, upd sm.CommunityUpdat
 		com.Banner = *v
 	}
 
+	if v := upd.RequiresPostApproval; v != nil {
+		com.RequiresPostApproval = *v
+	}
+
+	if err := com.Validate(); err != nil {
+		return com, err
+	}
+
 	com.UpdatedAt = tx.now
 
 	args := []interface{}{
 		com.Name,
 		com.Description,
 		com.Banner,
+		com.RequiresPostApproval,
 		com.UpdatedAt,
 		com.ID,
 	}
-	query := `UPDATE "communities" SET "name" = $1, "description" = $2, "banner" = $3, "updated_at" = $4 WHERE "id" = $5`
+	query := `UPDATE "communities" SET "name" = $1, "description" = $2, "banner" = $3, "requires_post_approval" = $4, "updated_at" = $5 WHERE "id" = $6`
 
 	_, err = tx.ExecContext(ctx, query, args...)
 	if err != nil {
-		return com, err
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "communities_name_key"`:
+			return com, sm.Errorf(sm.ECONFLICT, "this community name already exists.")
+		default:
+			return com, err
+		}
 	}
 
-	return com, err
+	return com, nil
 }
 
 func deleteCommunity(ctx context.Context, tx *Tx, id uint) error {
@@ -199,3 +243,18 @@ func deleteCommunity(ctx context.Context, tx *Tx, id uint) error {
 
 	return nil
 }
+
+// canModifyCommunity reports whether ctx holds an admin or a user moderating
+// the community.
+func canModifyCommunity(ctx context.Context, tx *Tx, communityID uint) (bool, error) {
+	if sm.AdminFromContext(ctx) != nil {
+		return true, nil
+	}
+
+	userID := sm.UserIDFromContext(ctx)
+	if userID == 0 {
+		return false, nil
+	}
+
+	return isCommunityModerator(ctx, tx, communityID, userID)
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 
 	sm "github.com/maliByatzes/socialmedia"
 )
@@ -32,11 +33,11 @@ func (s *CBUService) CreateCBU(ctx context.Context, cbu *sm.CBU) error {
 	return tx.Commit()
 }
 
-func (s *CBUService) DeleteCBU(ctx context.Context, id uint) error {
+func (s *CBUService) DeleteCBU(ctx context.Context, communityID, userID uint) error {
 	tx := s.db.BeginTx(ctx, nil)
 	defer tx.Rollback()
 
-	if err := deleteCBU(ctx, tx, id); err != nil {
+	if err := deleteCBU(ctx, tx, communityID, userID); err != nil {
 		return err
 	} else if err := tx.Commit(); err != nil {
 		return err
@@ -46,13 +47,120 @@ func (s *CBUService) DeleteCBU(ctx context.Context, id uint) error {
 }
 
 func findCBUs(ctx context.Context, tx *Tx, filter sm.CBUFilter) (_ []*sm.CBU, n int, err error) {
+	where, args := []string{}, []interface{}{}
+	argPos := 1
 
+	if v := filter.CommunityID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"community_id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.UserID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"user_id" = $%d`, argPos)), append(args, *v)
+	}
+
+	query := `SELECT "community_id", "user_id", "banned_at", COUNT(*) OVER()
+	FROM "community_banned_users"` + formatWhereClause(where) + ` ORDER BY "banned_at" ASC` + formatLimitOffset(filter.Limit, filter.Offset)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, n, err
+	}
+	defer rows.Close()
+
+	cbus := make([]*sm.CBU, 0)
+	for rows.Next() {
+		var cbu sm.CBU
+		if err := rows.Scan(
+			&cbu.CommunityID,
+			&cbu.UserID,
+			(*NullTime)(&cbu.BannedAt),
+			&n,
+		); err != nil {
+			return nil, n, err
+		}
+
+		cbus = append(cbus, &cbu)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return cbus, n, nil
+}
+
+func isBannedFromCommunity(ctx context.Context, tx *Tx, communityID, userID uint) (bool, error) {
+	_, n, err := findCBUs(ctx, tx, sm.CBUFilter{CommunityID: &communityID, UserID: &userID})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
 }
 
 func createCBU(ctx context.Context, tx *Tx, cbu *sm.CBU) error {
+	if _, err := findCommunityByID(ctx, tx, cbu.CommunityID); err != nil {
+		return err
+	}
+
+	if ok, err := canModifyCommunity(ctx, tx, cbu.CommunityID); err != nil {
+		return err
+	} else if !ok {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to ban users from this community.")
+	} else if sm.UserIDFromContext(ctx) == cbu.UserID {
+		return sm.Errorf(sm.EINVALID, "You cannot ban yourself.")
+	}
+
+	if _, err := findUserByID(ctx, tx, cbu.UserID); err != nil {
+		return err
+	}
 
+	cbu.BannedAt = tx.now
+
+	query := `INSERT INTO "community_banned_users" ("community_id", "user_id", "banned_at")
+	VALUES ($1, $2, $3)`
+	args := []interface{}{
+		cbu.CommunityID,
+		cbu.UserID,
+		(*NullTime)(&cbu.BannedAt),
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "community_banned_users_community_id_user_id_key"`:
+			return sm.Errorf(sm.ECONFLICT, "This user is already banned from this community.")
+		default:
+			return err
+		}
+	}
+
+	// A banned user loses their membership straight away.
+	query = `DELETE FROM "community_members" WHERE "community_id" = $1 AND "user_id" = $2`
+
+	if _, err := tx.ExecContext(ctx, query, cbu.CommunityID, cbu.UserID); err != nil {
+		return err
+	}
+
+	return nil
 }
 
-func deleteCBU(ctx context.Context, tx *Tx, id uint) error {
+func deleteCBU(ctx context.Context, tx *Tx, communityID, userID uint) error {
+	if ok, err := canModifyCommunity(ctx, tx, communityID); err != nil {
+		return err
+	} else if !ok {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to unban users from this community.")
+	}
+
+	if ok, err := isBannedFromCommunity(ctx, tx, communityID, userID); err != nil {
+		return err
+	} else if !ok {
+		return sm.Errorf(sm.ENOTFOUND, "Banned user not found.")
+	}
 
+	query := `DELETE FROM "community_banned_users" WHERE "community_id" = $1 AND "user_id" = $2`
+
+	if _, err := tx.ExecContext(ctx, query, communityID, userID); err != nil {
+		return err
+	}
+
+	return nil
 }
@@ -33,11 +33,11 @@ func (s *CommunityMemberService) CreateCommunityMember(ctx context.Context, cm *
 	return tx.Commit()
 }
 
-func (s *CommunityMemberService) UpdateCommunityMember(ctx context.Context, id uint, upd sm.CommunityMemberUpdate) (*sm.CommunityMember, error) {
+func (s *CommunityMemberService) UpdateCommunityMember(ctx context.Context, communityID, userID uint, upd sm.CommunityMemberUpdate) (*sm.CommunityMember, error) {
 	tx := s.db.BeginTx(ctx, nil)
 	defer tx.Rollback()
 
-	cm, err := updateCommunityMember(ctx, tx, id, upd)
+	cm, err := updateCommunityMember(ctx, tx, communityID, userID, upd)
 	if err != nil {
 		return cm, err
 	} else if err := tx.Commit(); err != nil {
@@ -47,17 +47,37 @@ func (s *CommunityMemberService) UpdateCommunityMember(ctx context.Context, id u
 	return cm, nil
 }
 
-func (s *CommunityMemberService) DeleteCommunityMember(ctx context.Context, id uint) error {
+func (s *CommunityMemberService) DeleteCommunityMember(ctx context.Context, communityID, userID uint) error {
 	tx := s.db.BeginTx(ctx, nil)
 	defer tx.Rollback()
 
-	if err := deleteCommunityMember(ctx, tx, id); err != nil {
+	if err := deleteCommunityMember(ctx, tx, communityID, userID); err != nil {
 		return err
 	}
 
 	return tx.Commit()
 }
 
+func findCommunityMember(ctx context.Context, tx *Tx, communityID, userID uint) (*sm.CommunityMember, error) {
+	a, _, err := findCommunityMembers(ctx, tx, sm.CommunityMemberFilter{CommunityID: &communityID, UserID: &userID})
+	if err != nil {
+		return nil, err
+	} else if len(a) == 0 {
+		return nil, &sm.Error{Code: sm.ENOTFOUND, Message: "Community member not found."}
+	}
+	return a[0], nil
+}
+
+func isCommunityModerator(ctx context.Context, tx *Tx, communityID, userID uint) (bool, error) {
+	cm, err := findCommunityMember(ctx, tx, communityID, userID)
+	if sm.ErrorCode(err) == sm.ENOTFOUND {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return cm.IsModerator, nil
+}
+
 func findCommunityMembers(ctx context.Context, tx *Tx, filter sm.CommunityMemberFilter) (_ []*sm.CommunityMember, n int, err error) {
 	where, args := []string{}, []interface{}{}
 	argPos := 1
@@ -77,7 +97,7 @@ func findCommunityMembers(ctx context.Context, tx *Tx, filter sm.CommunityMember
 	}
 
 	query := `SELECT "community_id", "user_id", "is_moderator", "created_at", "updated_at", COUNT(*) OVER()
-	FROM "community_members"` + formatWhereClause(where) + ` ORDER BY id ASC` + formatLimitOffset(filter.Limit, filter.Offset)
+	FROM "community_members"` + formatWhereClause(where) + ` ORDER BY "created_at" ASC` + formatLimitOffset(filter.Limit, filter.Offset)
 
 	rows, err := tx.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -109,6 +129,20 @@ func findCommunityMembers(ctx context.Context, tx *Tx, filter sm.CommunityMember
 }
 
 func createCommunityMember(ctx context.Context, tx *Tx, cm *sm.CommunityMember) error {
+	if sm.UserIDFromContext(ctx) != cm.UserID {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to join on behalf of this user.")
+	}
+
+	if _, err := findCommunityByID(ctx, tx, cm.CommunityID); err != nil {
+		return err
+	}
+
+	if banned, err := isBannedFromCommunity(ctx, tx, cm.CommunityID, cm.UserID); err != nil {
+		return err
+	} else if banned {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are banned from this community.")
+	}
+
 	cm.CreatedAt = tx.now
 	cm.UpdatedAt = cm.CreatedAt
 
@@ -123,13 +157,60 @@ func createCommunityMember(ctx context.Context, tx *Tx, cm *sm.CommunityMember)
 	}
 
 	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
-		return err
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "community_members_community_id_user_id_key"`:
+			return sm.Errorf(sm.ECONFLICT, "You are already a member of this community.")
+		default:
+			return err
+		}
 	}
 
 	return nil
 }
 
-func updateCommunityMember(ctx context.Context, tx *Tx, id uint, upd sm.CommunityMemberUpdate) (*sm.CommunityMember, error) {
+func updateCommunityMember(ctx context.Context, tx *Tx, communityID, userID uint, upd sm.CommunityMemberUpdate) (*sm.CommunityMember, error) {
+	if sm.AdminFromContext(ctx) == nil {
+		return nil, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to update this member.")
+	}
+
+	cm, err := findCommunityMember(ctx, tx, communityID, userID)
+	if err != nil {
+		return cm, err
+	}
+
+	if v := upd.IsModerator; v != nil {
+		cm.IsModerator = *v
+	}
+
+	cm.UpdatedAt = tx.now
+
+	args := []interface{}{
+		cm.IsModerator,
+		(*NullTime)(&cm.UpdatedAt),
+		cm.CommunityID,
+		cm.UserID,
+	}
+	query := `UPDATE "community_members" SET "is_moderator" = $1, "updated_at" = $2 WHERE "community_id" = $3 AND "user_id" = $4`
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return cm, err
+	}
+
+	return cm, nil
 }
 
-func deleteCommunityMember(cxt context.Context, tx *Tx, id uint) error {}
+func deleteCommunityMember(ctx context.Context, tx *Tx, communityID, userID uint) error {
+	if cm, err := findCommunityMember(ctx, tx, communityID, userID); err != nil {
+		return err
+	} else if sm.UserIDFromContext(ctx) != cm.UserID {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to remove this member.")
+	}
+
+	query := `DELETE FROM "community_members" WHERE "community_id" = $1 AND "user_id" = $2`
+
+	if _, err := tx.ExecContext(ctx, query, communityID, userID); err != nil {
+		return err
+	}
+
+	return nil
+}
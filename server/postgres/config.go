@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+var _ sm.ConfigService = (*ConfigService)(nil)
+
+type ConfigService struct {
+	db *DB
+}
+
+func NewConfigService(db *DB) *ConfigService {
+	return &ConfigService{db: db}
+}
+
+func (s *ConfigService) FindConfig(ctx context.Context) (*sm.Config, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	return findConfig(ctx, tx)
+}
+
+func (s *ConfigService) UpdateConfig(ctx context.Context, upd sm.ConfigUpdate) (*sm.Config, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	config, err := updateConfig(ctx, tx, upd)
+	if err != nil {
+		return config, err
+	} else if err := tx.Commit(); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+// findConfig returns the first row of the configs table, or the defaults when
+// the table is empty.
+func findConfig(ctx context.Context, tx *Tx) (*sm.Config, error) {
+	query := `SELECT "id", COALESCE("use_perspective_api", FALSE), "category_filtering_service_provider",
+	COALESCE("category_filtering_request_timeout", 0)
+	FROM "configs" ORDER BY "id" ASC LIMIT 1`
+
+	var config sm.Config
+	if err := tx.QueryRowxContext(ctx, query).Scan(
+		&config.ID,
+		&config.UsePerspectiveAPI,
+		(*NullString)(&config.CategoryFilteringServiceProvider),
+		&config.CategoryFilteringRequestTimeout,
+	); err == sql.ErrNoRows {
+		return &sm.Config{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// updateConfig updates the configs row, creating it if the table is empty.
+func updateConfig(ctx context.Context, tx *Tx, upd sm.ConfigUpdate) (*sm.Config, error) {
+	if sm.AdminFromContext(ctx) == nil {
+		return nil, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to update the config.")
+	}
+
+	config, err := findConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if v := upd.UsePerspectiveAPI; v != nil {
+		config.UsePerspectiveAPI = *v
+	}
+
+	if v := upd.CategoryFilteringServiceProvider; v != nil {
+		config.CategoryFilteringServiceProvider = *v
+	}
+
+	if v := upd.CategoryFilteringRequestTimeout; v != nil {
+		config.CategoryFilteringRequestTimeout = *v
+	}
+
+	if err := config.Validate(); err != nil {
+		return config, err
+	}
+
+	args := []interface{}{
+		config.UsePerspectiveAPI,
+		config.CategoryFilteringServiceProvider,
+		config.CategoryFilteringRequestTimeout,
+	}
+
+	if config.ID == 0 {
+		query := `INSERT INTO "configs" ("use_perspective_api", "category_filtering_service_provider", "category_filtering_request_timeout")
+		VALUES ($1, $2, $3) RETURNING id`
+
+		if err := tx.QueryRowxContext(ctx, query, args...).Scan(&config.ID); err != nil {
+			return config, err
+		}
+
+		return config, nil
+	}
+
+	query := `UPDATE "configs" SET "use_perspective_api" = $1, "category_filtering_service_provider" = $2,
+	"category_filtering_request_timeout" = $3 WHERE "id" = $4`
+
+	if _, err := tx.ExecContext(ctx, query, append(args, config.ID)...); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
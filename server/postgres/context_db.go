@@ -59,7 +59,17 @@ func (s *ContextService) CreateContext(ctx context.Context, context *sm.Context)
 }
 
 func (s *ContextService) UpdateContext(ctx context.Context, id uint, upd sm.ContextUpdate) (*sm.Context, error) {
-	panic("not implemented")
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	context, err := updateContext(ctx, tx, id, upd)
+	if err != nil {
+		return context, err
+	} else if err := tx.Commit(); err != nil {
+		return context, err
+	}
+
+	return context, nil
 }
 
 func (s *ContextService) DeleteContext(ctx context.Context, id uint) error {
@@ -74,23 +84,25 @@ func createContext(ctx context.Context, tx *Tx, context *sm.Context) error {
 		return err
 	}
 
-	query := `INSERT INTO "context" ("user_id", "email", "ip", "country", "city", "browser", "platform", "os", "device", "device_type", "is_trusted", "created_at", "updated_at") 
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`
-	args := []interface{}{
-		context.UserID,
-		context.Email,
-		utils.EncryptData([]byte(context.IP)),
-		utils.EncryptData([]byte(context.Country)),
-		utils.EncryptData([]byte(context.City)),
-		utils.EncryptData([]byte(context.Browser)),
-		utils.EncryptData([]byte(context.Platform)),
-		utils.EncryptData([]byte(context.OS)),
-		utils.EncryptData([]byte(context.Device)),
-		utils.EncryptData([]byte(context.DeviceType)),
+	encrypted := make([]interface{}, 0, 8)
+	for _, field := range encryptedContextFields(context) {
+		v, err := utils.EncryptData(tx.db.CryptoKey, []byte(*field))
+		if err != nil {
+			return err
+		}
+		encrypted = append(encrypted, v)
+	}
+
+	query := `INSERT INTO "context" ("user_id", "email", "ip", "country", "city", "browser", "platform", "os", "device", "device_type", "is_trusted", "is_primary", "created_at", "updated_at") 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id`
+	args := []interface{}{context.UserID, context.Email}
+	args = append(args, encrypted...)
+	args = append(args,
 		context.IsTrusted,
+		context.IsPrimary,
 		(*NullTime)(&context.CreatedAt),
 		(*NullTime)(&context.UpdatedAt),
-	}
+	)
 
 	err := tx.QueryRowxContext(ctx, query, args...).Scan(&context.ID)
 	if err != nil {
@@ -100,6 +112,66 @@ func createContext(ctx context.Context, tx *Tx, context *sm.Context) error {
 	return nil
 }
 
+// updateContext replaces the stored context data. Only the user the context
+// belongs to may update it.
+func updateContext(ctx context.Context, tx *Tx, id uint, upd sm.ContextUpdate) (*sm.Context, error) {
+	context, err := findContextByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	} else if context.UserID != sm.UserIDFromContext(ctx) {
+		return nil, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to update this context.")
+	}
+
+	if v := upd.Email; v != nil {
+		context.Email = *v
+	}
+	if v := upd.IP; v != nil {
+		context.IP = *v
+	}
+	if v := upd.Country; v != nil {
+		context.Country = *v
+	}
+	if v := upd.City; v != nil {
+		context.City = *v
+	}
+	if v := upd.Browser; v != nil {
+		context.Browser = *v
+	}
+	if v := upd.Platform; v != nil {
+		context.Platform = *v
+	}
+	if v := upd.OS; v != nil {
+		context.OS = *v
+	}
+	if v := upd.Device; v != nil {
+		context.Device = *v
+	}
+	if v := upd.DeviceType; v != nil {
+		context.DeviceType = *v
+	}
+
+	context.UpdatedAt = tx.now
+
+	args := []interface{}{context.Email}
+	for _, field := range encryptedContextFields(context) {
+		v, err := utils.EncryptData(tx.db.CryptoKey, []byte(*field))
+		if err != nil {
+			return context, err
+		}
+		args = append(args, v)
+	}
+	args = append(args, (*NullTime)(&context.UpdatedAt), context.ID)
+
+	query := `UPDATE "context" SET "email" = $1, "ip" = $2, "country" = $3, "city" = $4, "browser" = $5, "platform" = $6,
+	"os" = $7, "device" = $8, "device_type" = $9, "updated_at" = $10 WHERE "id" = $11`
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return context, err
+	}
+
+	return context, nil
+}
+
 func findContextByID(ctx context.Context, tx *Tx, id uint) (*sm.Context, error) {
 	a, _, err := findContexts(ctx, tx, sm.ContextFilter{ID: &id})
 	if err != nil {
@@ -110,8 +182,10 @@ func findContextByID(ctx context.Context, tx *Tx, id uint) (*sm.Context, error)
 	return a[0], nil
 }
 
+// findContextByUserID returns the primary context of the user.
 func findContextByUserID(ctx context.Context, tx *Tx, userID uint) (*sm.Context, error) {
-	a, _, err := findContexts(ctx, tx, sm.ContextFilter{UserID: &userID})
+	isPrimary := true
+	a, _, err := findContexts(ctx, tx, sm.ContextFilter{UserID: &userID, IsPrimary: &isPrimary})
 	if err != nil {
 		return nil, err
 	} else if len(a) == 0 {
@@ -136,40 +210,12 @@ func findContexts(ctx context.Context, tx *Tx, filter sm.ContextFilter) (_ []*sm
 		where, args = append(where, fmt.Sprintf(`"email" = $%d`, argPos)), append(args, *v)
 		argPos++
 	}
-	if v := filter.IP; v != nil {
-		where, args = append(where, fmt.Sprintf(`"ip" = $%d`, argPos)), append(args, *v)
-		argPos++
-	}
-	if v := filter.Country; v != nil {
-		where, args = append(where, fmt.Sprintf(`"country" = $%d`, argPos)), append(args, *v)
-		argPos++
-	}
-	if v := filter.City; v != nil {
-		where, args = append(where, fmt.Sprintf(`"city" = $%d`, argPos)), append(args, *v)
-		argPos++
-	}
-	if v := filter.Browser; v != nil {
-		where, args = append(where, fmt.Sprintf(`"browser" = $%d`, argPos)), append(args, *v)
-		argPos++
-	}
-	if v := filter.Platform; v != nil {
-		where, args = append(where, fmt.Sprintf(`"platform" = $%d`, argPos)), append(args, *v)
-		argPos++
-	}
-	if v := filter.OS; v != nil {
-		where, args = append(where, fmt.Sprintf(`"os" = $%d`, argPos)), append(args, *v)
-		argPos++
-	}
-	if v := filter.Device; v != nil {
-		where, args = append(where, fmt.Sprintf(`"device" = $%d`, argPos)), append(args, *v)
-		argPos++
-	}
-	if v := filter.DeviceType; v != nil {
-		where, args = append(where, fmt.Sprintf(`"device_type" = $%d`, argPos)), append(args, *v)
+	if v := filter.IsPrimary; v != nil {
+		where, args = append(where, fmt.Sprintf(`"is_primary" = $%d`, argPos)), append(args, *v)
 	}
 
-	query := `SELECT "id", "user_id", "email", "ip", "country", "city", "browser", "platform", "os", "device", "device_type", "is_trusted", "created_at", "updated_at", COUNT(*) OVER() 
-	 FROM "context" WHERE` + formatWhereClause(where) + ` ORDER BY id ASC` + formatLimitOffset(filter.Limit, filter.Offset)
+	query := `SELECT "id", "user_id", "email", "ip", "country", "city", "browser", "platform", "os", "device", "device_type", "is_trusted", "is_primary", "created_at", "updated_at", COUNT(*) OVER()
+	 FROM "context"` + formatWhereClause(where) + ` ORDER BY id ASC` + formatLimitOffset(filter.Limit, filter.Offset)
 
 	rows, err := tx.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -193,6 +239,7 @@ func findContexts(ctx context.Context, tx *Tx, filter sm.ContextFilter) (_ []*sm
 			(*NullString)(&context.Device),
 			(*NullString)(&context.DeviceType),
 			&context.IsTrusted,
+			&context.IsPrimary,
 			(*NullTime)(&context.CreatedAt),
 			(*NullTime)(&context.UpdatedAt),
 			&n,
@@ -200,15 +247,11 @@ func findContexts(ctx context.Context, tx *Tx, filter sm.ContextFilter) (_ []*sm
 			return nil, n, err
 		}
 
-		// NOTE: Will this even work?? IDK we'll see
-		context.IP = utils.DecryptData([]byte(context.IP))
-		context.Country = utils.DecryptData([]byte(context.Country))
-		context.City = utils.DecryptData([]byte(context.City))
-		context.Browser = utils.DecryptData([]byte(context.Browser))
-		context.Platform = utils.DecryptData([]byte(context.Platform))
-		context.OS = utils.DecryptData([]byte(context.OS))
-		context.Device = utils.DecryptData([]byte(context.Device))
-		context.DeviceType = utils.DecryptData([]byte(context.DeviceType))
+		for _, field := range encryptedContextFields(&context) {
+			if *field, err = utils.DecryptData(tx.db.CryptoKey, *field); err != nil {
+				return nil, 0, err
+			}
+		}
 
 		contexts = append(contexts, &context)
 	}
@@ -218,3 +261,18 @@ func findContexts(ctx context.Context, tx *Tx, filter sm.ContextFilter) (_ []*sm
 
 	return contexts, n, nil
 }
+
+// encryptedContextFields returns the fields of context that are stored
+// encrypted, in column order.
+func encryptedContextFields(context *sm.Context) []*string {
+	return []*string{
+		&context.IP,
+		&context.Country,
+		&context.City,
+		&context.Browser,
+		&context.Platform,
+		&context.OS,
+		&context.Device,
+		&context.DeviceType,
+	}
+}
@@ -18,6 +18,9 @@ type DB struct {
 	cancel func()
 	DSN    string
 	Now    func() time.Time
+
+	// CryptoKey encrypts the device data stored with contexts.
+	CryptoKey string
 }
 
 func NewDB(dsn string) *DB {
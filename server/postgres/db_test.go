@@ -0,0 +1,71 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/maliByatzes/socialmedia/postgres"
+)
+
+// MustOpenDB opens the database in TEST_DB_URL inside a schema of its own,
+// with every migration applied, and drops the schema when the test ends.
+// Tests using it are skipped when TEST_DB_URL is not set.
+func MustOpenDB(tb testing.TB) *postgres.DB {
+	tb.Helper()
+
+	dsn, ok := os.LookupEnv("TEST_DB_URL")
+	if !ok {
+		tb.Skip("TEST_DB_URL is not set")
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec(`CREATE SCHEMA ` + schema); err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE`) })
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+
+	db := postgres.NewDB(u.String())
+	db.CryptoKey = "0123456789abcdef"
+	if err := db.Open(); err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { db.Close() })
+
+	migrations, err := filepath.Glob("migrations/*.up.sql")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	sort.Strings(migrations)
+
+	for _, name := range migrations {
+		buf, err := os.ReadFile(name)
+		if err != nil {
+			tb.Fatal(err)
+		} else if _, err := db.DB.Exec(string(buf)); err != nil {
+			tb.Fatalf("%s: %v", name, err)
+		}
+	}
+
+	return db
+}
@@ -48,6 +48,31 @@ func (s *EmailService) CreateEmailVerification(ctx context.Context, email *sm.Em
 	return tx.Commit()
 }
 
+func (s *EmailService) ConsumeEmailVerification(ctx context.Context, email, code, purpose string) (*sm.Email, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	ev, err := consumeEmailVerification(ctx, tx, email, code, purpose)
+	if err != nil && sm.ErrorCode(err) != sm.EINVALID {
+		return nil, err
+	} else if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return ev, err
+}
+
+func (s *EmailService) ReserveEmailVerification(ctx context.Context, email *sm.Email, cooldown time.Duration) (*sm.Email, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if ev, err := reserveEmailVerification(ctx, tx, email, cooldown); err != nil {
+		return ev, err
+	}
+
+	return nil, tx.Commit()
+}
+
 func findEmailVerificationByID(ctx context.Context, tx *Tx, id uint) (*sm.Email, error) {
 	a, _, err := findEmailVerifications(ctx, tx, sm.EmailFilter{ID: &id})
 	if err != nil {
@@ -81,13 +106,14 @@ func findEmailVerifications(ctx context.Context, tx *Tx, filter sm.EmailFilter)
 		where, args = append(where, fmt.Sprintf(`"for" = $%d`, argPos)), append(args, *v)
 	}
 
-	query := `SELECT "id", "email", "verification_code", "message_id", "for", "created_at", "expired_at", COUNT(*) OVER()
+	query := `SELECT "id", "email", "verification_code", "message_id", "for", "created_at", "expires_at", COUNT(*) OVER()
 	FROM "emails"` + formatWhereClause(where) + ` ORDER BY id ASC` + formatLimitOffset(filter.Limit, filter.Offset)
 
 	rows, err := tx.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, n, err
 	}
+	defer rows.Close()
 
 	emails := make([]*sm.Email, 0)
 	for rows.Next() {
@@ -138,3 +164,69 @@ func createEmailVerification(ctx context.Context, tx *Tx, email *sm.Email) error
 
 	return nil
 }
+
+// reserveEmailVerification holds a transaction scoped advisory lock on the
+// address and purpose while it checks for a recent verification, so two
+// requests cannot both pass the check.
+func reserveEmailVerification(ctx context.Context, tx *Tx, email *sm.Email, cooldown time.Duration) (*sm.Email, error) {
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, email.For+":"+email.Email); err != nil {
+		return nil, err
+	}
+
+	evs, _, err := findEmailVerifications(ctx, tx, sm.EmailFilter{Email: &email.Email, For: &email.For})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ev := range evs {
+		if ev.CreatedAt.After(tx.now.Add(-cooldown)) {
+			return ev, sm.Errorf(sm.ECONFLICT, "A verification was sent recently.")
+		}
+	}
+
+	return nil, createEmailVerification(ctx, tx, email)
+}
+
+// consumeEmailVerification deletes the matching verifications in a single
+// statement, so two requests cannot both use the same code.
+func consumeEmailVerification(ctx context.Context, tx *Tx, email, code, purpose string) (*sm.Email, error) {
+	query := `DELETE FROM "emails" WHERE "email" = $1 AND "verification_code" = $2 AND "for" = $3
+	RETURNING "id", "email", "verification_code", "message_id", "for", "created_at", "expires_at"`
+
+	rows, err := tx.QueryContext(ctx, query, email, code, purpose)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var latest *sm.Email
+	for rows.Next() {
+		var ev sm.Email
+		if err := rows.Scan(
+			&ev.ID,
+			(*NullString)(&ev.Email),
+			(*NullString)(&ev.VerificationCode),
+			(*NullString)(&ev.MessageID),
+			(*NullString)(&ev.For),
+			(*NullTime)(&ev.CreatedAt),
+			(*NullTime)(&ev.ExpiresAt),
+		); err != nil {
+			return nil, err
+		}
+
+		if latest == nil || ev.ExpiresAt.After(latest.ExpiresAt) {
+			latest = &ev
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if latest == nil {
+		return nil, sm.Errorf(sm.ENOTFOUND, "Verification not found.")
+	} else if !latest.ExpiresAt.After(tx.now) {
+		return latest, sm.Errorf(sm.EINVALID, "Verification has expired.")
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+var _ sm.LogService = (*LogService)(nil)
+
+type LogService struct {
+	db *DB
+}
+
+func NewLogService(db *DB) *LogService {
+	return &LogService{db: db}
+}
+
+func (s *LogService) FindLogs(ctx context.Context, filter sm.LogFilter) ([]*sm.Log, int, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	return findLogs(ctx, tx, filter)
+}
+
+func (s *LogService) CreateLog(ctx context.Context, log *sm.Log) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := createLog(ctx, tx, log); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *LogService) DeleteLogsBefore(ctx context.Context, t time.Time) (int, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	n, err := deleteLogsBefore(ctx, tx, t)
+	if err != nil {
+		return 0, err
+	} else if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func findLogs(ctx context.Context, tx *Tx, filter sm.LogFilter) (_ []*sm.Log, n int, err error) {
+	where, args := []string{}, []interface{}{}
+	argPos := 1
+
+	if v := filter.Email; v != nil {
+		where, args = append(where, fmt.Sprintf(`"email" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.Type; v != nil {
+		where, args = append(where, fmt.Sprintf(`"type" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.Level; v != nil {
+		where, args = append(where, fmt.Sprintf(`"level" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.From; v != nil {
+		where, args = append(where, fmt.Sprintf(`"created_at" >= $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.To; v != nil {
+		where, args = append(where, fmt.Sprintf(`"created_at" < $%d`, argPos)), append(args, *v)
+	}
+
+	query := `SELECT "id", "email", "context", "message", "type", "level", "created_at", COUNT(*) OVER()
+	FROM "logs"` + formatWhereClause(where) + ` ORDER BY "created_at" DESC, "id" DESC` + formatLimitOffset(filter.Limit, filter.Offset)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, n, err
+	}
+	defer rows.Close()
+
+	logs := make([]*sm.Log, 0)
+	for rows.Next() {
+		var log sm.Log
+		if err := rows.Scan(
+			&log.ID,
+			(*NullString)(&log.Email),
+			(*NullString)(&log.Context),
+			(*NullString)(&log.Message),
+			(*NullString)(&log.Type),
+			(*NullString)(&log.Level),
+			(*NullTime)(&log.CreatedAt),
+			&n,
+		); err != nil {
+			return nil, n, err
+		}
+
+		logs = append(logs, &log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return logs, n, nil
+}
+
+func createLog(ctx context.Context, tx *Tx, log *sm.Log) error {
+	log.CreatedAt = tx.now
+
+	query := `INSERT INTO "logs" ("email", "context", "message", "type", "level", "created_at", "updated_at")
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	args := []interface{}{
+		log.Email,
+		log.Context,
+		log.Message,
+		log.Type,
+		log.Level,
+		(*NullTime)(&log.CreatedAt),
+		(*NullTime)(&log.CreatedAt),
+	}
+
+	if err := tx.QueryRowxContext(ctx, query, args...).Scan(&log.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func deleteLogsBefore(ctx context.Context, tx *Tx, t time.Time) (int, error) {
+	query := `DELETE FROM "logs" WHERE "created_at" < $1`
+
+	result, err := tx.ExecContext(ctx, query, t)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(n), nil
+}
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "communities_name_key";
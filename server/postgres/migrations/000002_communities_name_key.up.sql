@@ -0,0 +1 @@
+CREATE UNIQUE INDEX "communities_name_key" ON "communities"("name");
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "community_members_community_id_user_id_key";
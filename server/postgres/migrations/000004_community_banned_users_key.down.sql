@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "community_banned_users_community_id_user_id_key";
@@ -0,0 +1 @@
+CREATE UNIQUE INDEX "community_banned_users_community_id_user_id_key" ON "community_banned_users"("community_id", "user_id");
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "comments_post_id_idx";
+
+ALTER TABLE "comments" DROP CONSTRAINT IF EXISTS "comments_parent_id_fkey";
+
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "parent_id";
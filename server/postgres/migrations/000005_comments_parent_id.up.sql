@@ -0,0 +1,5 @@
+ALTER TABLE "comments" ADD COLUMN "parent_id" INTEGER;
+
+ALTER TABLE "comments" ADD CONSTRAINT "comments_parent_id_fkey" FOREIGN KEY ("parent_id") REFERENCES "comments"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+CREATE INDEX "comments_post_id_idx" ON "comments"("post_id");
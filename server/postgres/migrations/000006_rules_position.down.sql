@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "rules_community_id_idx";
+
+ALTER TABLE "rules" DROP COLUMN IF EXISTS "position";
@@ -0,0 +1,7 @@
+ALTER TABLE "rules" ADD COLUMN "position" INTEGER NOT NULL DEFAULT 0;
+
+UPDATE "rules" SET "position" = "numbered"."position"
+FROM (SELECT "id", ROW_NUMBER() OVER (PARTITION BY "community_id" ORDER BY "id") AS "position" FROM "rules") AS "numbered"
+WHERE "rules"."id" = "numbered"."id";
+
+CREATE INDEX "rules_community_id_idx" ON "rules"("community_id");
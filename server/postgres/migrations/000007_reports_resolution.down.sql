@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS "reports_community_id_status_idx";
+
+ALTER TABLE "reports" DROP CONSTRAINT IF EXISTS "reports_post_id_reported_by_key";
+
+ALTER TABLE "reports" DROP CONSTRAINT IF EXISTS "reports_post_id_fkey";
+ALTER TABLE "reports" ADD CONSTRAINT "reports_post_id_fkey" FOREIGN KEY ("post_id") REFERENCES "posts"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
+
+ALTER TABLE "reports" DROP CONSTRAINT IF EXISTS "reports_resolved_by_fkey";
+
+ALTER TABLE "reports" DROP COLUMN IF EXISTS "resolved_at";
+ALTER TABLE "reports" DROP COLUMN IF EXISTS "resolved_by";
+ALTER TABLE "reports" DROP COLUMN IF EXISTS "resolution";
+ALTER TABLE "reports" DROP COLUMN IF EXISTS "status";
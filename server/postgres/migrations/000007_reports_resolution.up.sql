@@ -0,0 +1,14 @@
+ALTER TABLE "reports" ADD COLUMN "status" VARCHAR(20) NOT NULL DEFAULT 'open';
+ALTER TABLE "reports" ADD COLUMN "resolution" VARCHAR(20);
+ALTER TABLE "reports" ADD COLUMN "resolved_by" INTEGER;
+ALTER TABLE "reports" ADD COLUMN "resolved_at" TIMESTAMPTZ;
+
+ALTER TABLE "reports" ADD CONSTRAINT "reports_resolved_by_fkey" FOREIGN KEY ("resolved_by") REFERENCES "users"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
+
+-- Resolved reports are kept as a record after the post is removed.
+ALTER TABLE "reports" DROP CONSTRAINT IF EXISTS "reports_post_id_fkey";
+ALTER TABLE "reports" ADD CONSTRAINT "reports_post_id_fkey" FOREIGN KEY ("post_id") REFERENCES "posts"("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+ALTER TABLE "reports" ADD CONSTRAINT "reports_post_id_reported_by_key" UNIQUE ("post_id", "reported_by");
+
+CREATE INDEX "reports_community_id_status_idx" ON "reports"("community_id", "status");
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "pending_posts_community_id_status_idx";
+
+ALTER TABLE "pending_posts" ALTER COLUMN "status" DROP NOT NULL;
+ALTER TABLE "pending_posts" ALTER COLUMN "status" DROP DEFAULT;
+
+ALTER TABLE "communities" DROP COLUMN IF EXISTS "requires_post_approval";
@@ -0,0 +1,7 @@
+ALTER TABLE "communities" ADD COLUMN "requires_post_approval" BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE "pending_posts" SET "status" = 'pending' WHERE "status" IS NULL;
+ALTER TABLE "pending_posts" ALTER COLUMN "status" SET DEFAULT 'pending';
+ALTER TABLE "pending_posts" ALTER COLUMN "status" SET NOT NULL;
+
+CREATE INDEX "pending_posts_community_id_status_idx" ON "pending_posts"("community_id", "status");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "logs_email_idx";
+DROP INDEX IF EXISTS "logs_created_at_idx";
@@ -0,0 +1,2 @@
+CREATE INDEX "logs_created_at_idx" ON "logs"("created_at");
+CREATE INDEX "logs_email_idx" ON "logs"("email");
@@ -0,0 +1,9 @@
+ALTER TABLE "context"
+  ALTER COLUMN "ip" TYPE VARCHAR(45),
+  ALTER COLUMN "country" TYPE VARCHAR(100),
+  ALTER COLUMN "city" TYPE VARCHAR(100),
+  ALTER COLUMN "browser" TYPE VARCHAR(255),
+  ALTER COLUMN "platform" TYPE VARCHAR(255),
+  ALTER COLUMN "os" TYPE VARCHAR(100),
+  ALTER COLUMN "device" TYPE VARCHAR(255),
+  ALTER COLUMN "device_type" TYPE VARCHAR(255);
@@ -0,0 +1,9 @@
+ALTER TABLE "context"
+  ALTER COLUMN "ip" TYPE TEXT,
+  ALTER COLUMN "country" TYPE TEXT,
+  ALTER COLUMN "city" TYPE TEXT,
+  ALTER COLUMN "browser" TYPE TEXT,
+  ALTER COLUMN "platform" TYPE TEXT,
+  ALTER COLUMN "os" TYPE TEXT,
+  ALTER COLUMN "device" TYPE TEXT,
+  ALTER COLUMN "device_type" TYPE TEXT;
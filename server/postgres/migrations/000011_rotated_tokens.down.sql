@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "rotated_tokens";
@@ -0,0 +1,13 @@
+-- Refresh tokens that were rotated out, kept to detect their reuse.
+CREATE TABLE IF NOT EXISTS "rotated_tokens" (
+  "id" SERIAL NOT NULL,
+  "token_id" INTEGER,
+  "user_id" INTEGER,
+  "refresh_token" TEXT NOT NULL,
+  "rotated_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT "rotated_tokens_pkey" PRIMARY KEY ("id")
+);
+
+ALTER TABLE "rotated_tokens" ADD CONSTRAINT "rotated_tokens_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
+
+CREATE UNIQUE INDEX "rotated_tokens_refresh_token_key" ON "rotated_tokens"("refresh_token");
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "tokens_user_id_idx";
+DROP INDEX IF EXISTS "tokens_access_token_idx";
+
+ALTER TABLE "tokens" DROP CONSTRAINT IF EXISTS "tokens_context_id_fkey";
+ALTER TABLE "tokens" DROP COLUMN IF EXISTS "last_used_at";
+ALTER TABLE "tokens" DROP COLUMN IF EXISTS "context_id";
+
+ALTER TABLE "context" DROP COLUMN IF EXISTS "is_primary";
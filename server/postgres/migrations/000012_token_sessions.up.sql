@@ -0,0 +1,13 @@
+-- Every device a user signs in from gets a context. The one set at email
+-- verification, or the first seen, is the primary one.
+ALTER TABLE "context" ADD COLUMN "is_primary" BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE "context" SET "is_primary" = TRUE;
+
+ALTER TABLE "tokens" ADD COLUMN "context_id" INTEGER;
+ALTER TABLE "tokens" ADD COLUMN "last_used_at" TIMESTAMPTZ;
+UPDATE "tokens" SET "last_used_at" = "updated_at";
+
+ALTER TABLE "tokens" ADD CONSTRAINT "tokens_context_id_fkey" FOREIGN KEY ("context_id") REFERENCES "context"("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE INDEX "tokens_access_token_idx" ON "tokens"("access_token");
+CREATE INDEX "tokens_user_id_idx" ON "tokens"("user_id");
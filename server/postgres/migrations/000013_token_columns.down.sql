@@ -0,0 +1,5 @@
+ALTER TABLE "admin_tokens" ALTER COLUMN "access_token" TYPE VARCHAR(255);
+
+ALTER TABLE "tokens"
+  ALTER COLUMN "refresh_token" TYPE VARCHAR(255),
+  ALTER COLUMN "access_token" TYPE VARCHAR(255);
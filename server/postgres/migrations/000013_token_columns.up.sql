@@ -0,0 +1,6 @@
+-- PASETO tokens don't fit in 255 characters.
+ALTER TABLE "tokens"
+  ALTER COLUMN "refresh_token" TYPE TEXT,
+  ALTER COLUMN "access_token" TYPE TEXT;
+
+ALTER TABLE "admin_tokens" ALTER COLUMN "access_token" TYPE TEXT;
@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+var _ sm.PendingPostService = (*PendingPostService)(nil)
+
+type PendingPostService struct {
+	db *DB
+}
+
+func NewPendingPostService(db *DB) *PendingPostService {
+	return &PendingPostService{db: db}
+}
+
+func (s *PendingPostService) FindPendingPostByID(ctx context.Context, id uint) (*sm.PendingPost, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	post, err := findPendingPostByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (s *PendingPostService) FindPendingPosts(ctx context.Context, filter sm.PendingPostFilter) ([]*sm.PendingPost, int, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	return findPendingPosts(ctx, tx, filter)
+}
+
+func (s *PendingPostService) CreatePendingPost(ctx context.Context, post *sm.PendingPost) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := createPendingPost(ctx, tx, post); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *PendingPostService) ApprovePendingPost(ctx context.Context, id uint) (*sm.Post, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	post, err := approvePendingPost(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	} else if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (s *PendingPostService) RejectPendingPost(ctx context.Context, id uint) (*sm.PendingPost, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	post, err := rejectPendingPost(ctx, tx, id)
+	if err != nil {
+		return post, err
+	} else if err := tx.Commit(); err != nil {
+		return post, err
+	}
+
+	return post, nil
+}
+
+func findPendingPostByID(ctx context.Context, tx *Tx, id uint) (*sm.PendingPost, error) {
+	a, _, err := findPendingPosts(ctx, tx, sm.PendingPostFilter{ID: &id})
+	if err != nil {
+		return nil, err
+	} else if len(a) == 0 {
+		return nil, &sm.Error{Code: sm.ENOTFOUND, Message: "Pending post not found."}
+	}
+	return a[0], nil
+}
+
+func findPendingPosts(ctx context.Context, tx *Tx, filter sm.PendingPostFilter) (_ []*sm.PendingPost, n int, err error) {
+	where, args := []string{}, []interface{}{}
+	argPos := 1
+
+	if v := filter.ID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.CommunityID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"community_id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.UserID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"user_id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.Status; v != nil {
+		where, args = append(where, fmt.Sprintf(`"status" = $%d`, argPos)), append(args, *v)
+	}
+
+	query := `SELECT "id", "content", "file_url", "community_id", "user_id", "status", "created_at", "updated_at", COUNT(*) OVER()
+	FROM "pending_posts"` + formatWhereClause(where) + ` ORDER BY id ASC` + formatLimitOffset(filter.Limit, filter.Offset)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, n, err
+	}
+	defer rows.Close()
+
+	posts := make([]*sm.PendingPost, 0)
+	for rows.Next() {
+		var post sm.PendingPost
+		if err := rows.Scan(
+			&post.ID,
+			(*NullString)(&post.Content),
+			(*NullString)(&post.FileURL),
+			&post.CommunityID,
+			&post.UserID,
+			&post.Status,
+			(*NullTime)(&post.CreatedAt),
+			(*NullTime)(&post.UpdatedAt),
+			&n,
+		); err != nil {
+			return nil, n, err
+		}
+
+		posts = append(posts, &post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return posts, n, nil
+}
+
+func createPendingPost(ctx context.Context, tx *Tx, post *sm.PendingPost) error {
+	if sm.UserIDFromContext(ctx) != post.UserID {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to create this post.")
+	}
+
+	if err := post.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := findCommunityByID(ctx, tx, post.CommunityID); err != nil {
+		return err
+	}
+
+	if banned, err := isBannedFromCommunity(ctx, tx, post.CommunityID, post.UserID); err != nil {
+		return err
+	} else if banned {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are banned from this community.")
+	}
+
+	post.Status = sm.PendingPostStatusPending
+	post.CreatedAt = tx.now
+	post.UpdatedAt = post.CreatedAt
+
+	query := `INSERT INTO "pending_posts" ("content", "file_url", "community_id", "user_id", "status", "created_at", "updated_at")
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	args := []interface{}{
+		post.Content,
+		post.FileURL,
+		post.CommunityID,
+		post.UserID,
+		post.Status,
+		(*NullTime)(&post.CreatedAt),
+		(*NullTime)(&post.UpdatedAt),
+	}
+
+	if err := tx.QueryRowxContext(ctx, query, args...).Scan(&post.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// approvePendingPost publishes the pending post in its community and marks it
+// as approved.
+func approvePendingPost(ctx context.Context, tx *Tx, id uint) (*sm.Post, error) {
+	pending, err := reviewPendingPost(ctx, tx, id, sm.PendingPostStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	if banned, err := isBannedFromCommunity(ctx, tx, pending.CommunityID, pending.UserID); err != nil {
+		return nil, err
+	} else if banned {
+		return nil, sm.Errorf(sm.EINVALID, "The author is banned from this community.")
+	}
+
+	post := sm.Post{
+		Content:     pending.Content,
+		FileURL:     pending.FileURL,
+		CommunityID: pending.CommunityID,
+		UserID:      pending.UserID,
+		CreatedAt:   tx.now,
+		UpdatedAt:   tx.now,
+	}
+
+	query := `INSERT INTO "posts" ("content", "file_url", "community_id", "user_id", "created_at", "updated_at")
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	args := []interface{}{
+		post.Content,
+		post.FileURL,
+		post.CommunityID,
+		post.UserID,
+		(*NullTime)(&post.CreatedAt),
+		(*NullTime)(&post.UpdatedAt),
+	}
+
+	if err := tx.QueryRowxContext(ctx, query, args...).Scan(&post.ID); err != nil {
+		return nil, err
+	}
+
+	return &post, nil
+}
+
+func rejectPendingPost(ctx context.Context, tx *Tx, id uint) (*sm.PendingPost, error) {
+	return reviewPendingPost(ctx, tx, id, sm.PendingPostStatusRejected)
+}
+
+// reviewPendingPost moves a pending post to the given status, provided the
+// user in ctx moderates its community and it has not been reviewed yet.
+func reviewPendingPost(ctx context.Context, tx *Tx, id uint, status string) (*sm.PendingPost, error) {
+	post, err := findPendingPostByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok, err := canModifyCommunity(ctx, tx, post.CommunityID); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to review posts in this community.")
+	} else if post.Status != sm.PendingPostStatusPending {
+		return nil, sm.Errorf(sm.ECONFLICT, "This post has already been %s.", post.Status)
+	}
+
+	post.Status = status
+	post.UpdatedAt = tx.now
+
+	// The status check guards against two moderators reviewing the post at
+	// the same time.
+	query := `UPDATE "pending_posts" SET "status" = $1, "updated_at" = $2 WHERE "id" = $3 AND "status" = $4`
+
+	result, err := tx.ExecContext(ctx, query, post.Status, (*NullTime)(&post.UpdatedAt), post.ID, sm.PendingPostStatusPending)
+	if err != nil {
+		return nil, err
+	} else if n, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, sm.Errorf(sm.ECONFLICT, "This post has already been reviewed.")
+	}
+
+	return post, nil
+}
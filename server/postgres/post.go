@@ -96,9 +96,21 @@ func findPosts(ctx context.Context, tx *Tx, filter sm.PostFilter) (_ []*sm.Post,
 
 	if v := filter.UserID; v != nil {
 		where, args = append(where, fmt.Sprintf(`"user_id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	// Hide posts from communities the current user has been banned from.
+	if v := sm.UserIDFromContext(ctx); v != 0 {
+		where, args = append(where, fmt.Sprintf(`"community_id" NOT IN (SELECT "community_id" FROM "community_banned_users" WHERE "user_id" = $%d)`, argPos)), append(args, v)
 	}
 
-	query := `SELECT "id", "content", "file_url", "community_id", "user_id", "created_at", "update_at", COUNT(*) OVER()
+	// The like columns are computed for the current user, if any.
+	args = append(args, sm.UserIDFromContext(ctx))
+	query := `SELECT "id", "content", "file_url", "community_id", "user_id", "created_at", "updated_at",
+	(SELECT COUNT(*) FROM "post_likes" WHERE "post_likes"."post_id" = "posts"."id"),
+	EXISTS (SELECT 1 FROM "post_likes" WHERE "post_likes"."post_id" = "posts"."id" AND "post_likes"."user_id" = $` + fmt.Sprint(len(args)) + `),
+	(SELECT COUNT(*) FROM "comments" WHERE "comments"."post_id" = "posts"."id"),
+	COUNT(*) OVER()
 	FROM "posts"` + formatWhereClause(where) + ` ORDER BY id ASC` + formatLimitOffset(filter.Limit, filter.Offset)
 
 	rows, err := tx.QueryContext(ctx, query, args...)
@@ -118,6 +130,9 @@ func findPosts(ctx context.Context, tx *Tx, filter sm.PostFilter) (_ []*sm.Post,
 			&post.UserID,
 			(*NullTime)(&post.CreatedAt),
 			(*NullTime)(&post.UpdatedAt),
+			&post.LikeCount,
+			&post.LikedByMe,
+			&post.CommentCount,
 			&n,
 		); err != nil {
 			return nil, n, err
@@ -139,13 +154,37 @@ func createPost(ctx context.Context, tx *Tx, post *sm.Post) error {
 		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to create this post.")
 	}
 
+	if err := post.Validate(); err != nil {
+		return err
+	}
+
+	com, err := findCommunityByID(ctx, tx, post.CommunityID)
+	if err != nil {
+		return err
+	}
+
+	if banned, err := isBannedFromCommunity(ctx, tx, post.CommunityID, post.UserID); err != nil {
+		return err
+	} else if banned {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are banned from this community.")
+	}
+
+	// Only moderators can post straight away where posts need approval.
+	if com.RequiresPostApproval {
+		if ok, err := canModifyCommunity(ctx, tx, com.ID); err != nil {
+			return err
+		} else if !ok {
+			return sm.Errorf(sm.ENOTAUTHORIZED, "Posts in this community have to be approved by a moderator.")
+		}
+	}
+
 	post.CreatedAt = tx.now
 	post.UpdatedAt = post.CreatedAt
 
-	query := `INSERT INTO "posts" ("id", "content", "file_url", "community_id", "user_id", "created_at", "updated_at")
-	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	query := `INSERT INTO "posts" ("content", "file_url", "community_id", "user_id", "created_at", "updated_at")
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
 	args := []interface{}{
-		post.ID,
+		post.Content,
 		post.FileURL,
 		post.CommunityID,
 		post.UserID,
@@ -153,7 +192,7 @@ func createPost(ctx context.Context, tx *Tx, post *sm.Post) error {
 		(*NullTime)(&post.UpdatedAt),
 	}
 
-	err := tx.QueryRowxContext(ctx, query, args...).Scan(&post.ID)
+	err = tx.QueryRowxContext(ctx, query, args...).Scan(&post.ID)
 	if err != nil {
 		return err
 	}
@@ -167,13 +206,24 @@ func updatePost(ctx context.Context, tx *Tx, id uint, upd sm.PostUpdate) (*sm.Po
 		return nil, err
 	}
 
-	user, err := findUserByID(ctx, tx, post.UserID)
-	if err != nil {
+	if ok, err := canModifyPost(ctx, tx, post); err != nil {
 		return post, err
-	} else if sm.UserIDFromContext(ctx) != user.ID {
+	} else if !ok {
 		return nil, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to update this post.")
 	}
 
+	// Edits don't go through the pending queue, so where posts need approval
+	// only moderators can change them once approved.
+	if com, err := findCommunityByID(ctx, tx, post.CommunityID); err != nil {
+		return nil, err
+	} else if com.RequiresPostApproval {
+		if ok, err := canModifyCommunity(ctx, tx, com.ID); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, sm.Errorf(sm.ENOTAUTHORIZED, "Approved posts in this community can only be edited by a moderator.")
+		}
+	}
+
 	if v := upd.Content; v != nil {
 		post.Content = *v
 	}
@@ -182,16 +232,19 @@ func updatePost(ctx context.Context, tx *Tx, id uint, upd sm.PostUpdate) (*sm.Po
 		post.FileURL = *v
 	}
 
+	if err := post.Validate(); err != nil {
+		return post, err
+	}
+
 	post.UpdatedAt = tx.now
 
 	args := []interface{}{
 		post.Content,
 		post.FileURL,
-		post.UpdatedAt,
+		(*NullTime)(&post.UpdatedAt),
 		post.ID,
-		user.ID,
 	}
-	query := `UPDATE "posts" SET "content" = $1, "file_url" = $2, "updated_at" = $3 WHERE "id" = $4 and "user_id" = $5`
+	query := `UPDATE "posts" SET "content" = $1, "file_url" = $2, "updated_at" = $3 WHERE "id" = $4`
 
 	_, err = tx.ExecContext(ctx, query, args...)
 	if err != nil {
@@ -207,22 +260,46 @@ func deletePost(ctx context.Context, tx *Tx, id uint) error {
 		return err
 	}
 
-	user, err := findUserByID(ctx, tx, post.UserID)
-	if err != nil {
+	if ok, err := canModifyPost(ctx, tx, post); err != nil {
 		return err
-	} else if sm.UserIDFromContext(ctx) != user.ID {
+	} else if !ok {
 		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to delete this post.")
 	}
 
-	query := `DELETE FROM "posts" WHERE "id" = $1 AND "user_id" = $2`
-	args := []interface{}{
-		post.ID,
-		user.ID,
+	// Rows referencing the post are restricted by foreign keys, so they have
+	// to go first.
+	for _, table := range []string{"post_likes", "saved_posts", "comments"} {
+		query := fmt.Sprintf(`DELETE FROM "%s" WHERE "post_id" = $1`, table)
+		if _, err := tx.ExecContext(ctx, query, post.ID); err != nil {
+			return err
+		}
+	}
+
+	// Resolved reports stay behind as a record of the moderation action.
+	query := `DELETE FROM "reports" WHERE "post_id" = $1 AND "status" = $2`
+
+	if _, err := tx.ExecContext(ctx, query, post.ID, sm.ReportStatusOpen); err != nil {
+		return err
 	}
 
-	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+	query = `DELETE FROM "posts" WHERE "id" = $1`
+
+	if _, err := tx.ExecContext(ctx, query, post.ID); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// canModifyPost reports whether the user in ctx is the author of the post or
+// a moderator of the community it was posted in.
+func canModifyPost(ctx context.Context, tx *Tx, post *sm.Post) (bool, error) {
+	userID := sm.UserIDFromContext(ctx)
+	if userID == 0 {
+		return false, nil
+	} else if post.UserID == userID {
+		return true, nil
+	}
+
+	return isCommunityModerator(ctx, tx, post.CommunityID, userID)
+}
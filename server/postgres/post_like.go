@@ -33,11 +33,11 @@ func (s *PostLikeService) FindPostLikes(ctx context.Context, filter sm.PostLikeF
 	return findPostLikes(ctx, tx, filter)
 }
 
-func (s *PostLikeService) DeletePostLike(ctx context.Context, id uint) error {
+func (s *PostLikeService) DeletePostLike(ctx context.Context, postID, userID uint) error {
 	tx := s.db.BeginTx(ctx, nil)
 	defer tx.Rollback()
 
-	if err := deletePostLike(ctx, tx, id); err != nil {
+	if err := deletePostLike(ctx, tx, postID, userID); err != nil {
 		return err
 	}
 
@@ -53,6 +53,17 @@ func createPostLike(ctx context.Context, tx *Tx, postLike *sm.PostLike) error {
 		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to like this post.")
 	}
 
+	post, err := findPostByID(ctx, tx, postLike.PostID)
+	if err != nil {
+		return err
+	}
+
+	if banned, err := isBannedFromCommunity(ctx, tx, post.CommunityID, user.ID); err != nil {
+		return err
+	} else if banned {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are banned from this community.")
+	}
+
 	postLike.CreatedAt = tx.now
 
 	query := `INSERT INTO "post_likes" ("post_id", "user_id", "created_at")
@@ -64,7 +75,12 @@ func createPostLike(ctx context.Context, tx *Tx, postLike *sm.PostLike) error {
 	}
 
 	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
-		return err
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "post_likes_pkey"`:
+			return sm.Errorf(sm.ECONFLICT, "You have already liked this post.")
+		default:
+			return err
+		}
 	}
 
 	return nil
@@ -75,20 +91,28 @@ func findPostLikes(ctx context.Context, tx *Tx, filter sm.PostLikeFilter) (_ []*
 	argPos := 1
 
 	if v := filter.PostID; v != nil {
-		where, args = append(where, fmt.Sprintf(`"post_id" = $%d`, argPos)), append(args, *v)
+		where, args = append(where, fmt.Sprintf(`"post_likes"."post_id" = $%d`, argPos)), append(args, *v)
 		argPos++
 	}
 
 	if v := filter.UserID; v != nil {
-		where, args = append(where, fmt.Sprintf(`"user_id" = $%d`, argPos)), append(args, *v)
+		where, args = append(where, fmt.Sprintf(`"post_likes"."user_id" = $%d`, argPos)), append(args, *v)
 	}
 
+	columns := `"post_likes"."post_id", "post_likes"."user_id", "post_likes"."created_at"`
+	from := `"post_likes"`
+
+	// Filled likes embed the liking user and a summary of the liked post so
+	// callers don't have to look each of them up.
 	if filter.Fill {
-		// TODO: Return filled columns instead of `id`s
+		columns += `, "users"."name", "users"."avatar",
+		"posts"."content", "posts"."file_url", "posts"."community_id", "posts"."user_id", "posts"."created_at", "posts"."updated_at"`
+		from += ` JOIN "users" ON "users"."id" = "post_likes"."user_id"
+		JOIN "posts" ON "posts"."id" = "post_likes"."post_id"`
 	}
 
-	query := `SELECT "post_id", "user_id", "created_at", COUNT(*) OVER() 
-		FROM "post_likes"` + formatWhereClause(where) + ` ORDER BY "post_id" ASC` + formatLimitOffset(filter.Limit, filter.Offset)
+	query := `SELECT ` + columns + `, COUNT(*) OVER()
+		FROM ` + from + formatWhereClause(where) + ` ORDER BY "post_likes"."created_at" DESC` + formatLimitOffset(filter.Limit, filter.Offset)
 
 	rows, err := tx.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -99,15 +123,36 @@ func findPostLikes(ctx context.Context, tx *Tx, filter sm.PostLikeFilter) (_ []*
 	postLikes := make([]*sm.PostLike, 0)
 	for rows.Next() {
 		var postLike sm.PostLike
-		if err := rows.Scan(
+		dest := []interface{}{
 			&postLike.PostID,
 			&postLike.UserID,
 			(*NullTime)(&postLike.CreatedAt),
-			&n,
-		); err != nil {
+		}
+
+		if filter.Fill {
+			postLike.User = &sm.User{}
+			postLike.Post = &sm.Post{}
+			dest = append(dest,
+				&postLike.User.Name,
+				(*NullString)(&postLike.User.Avatar),
+				(*NullString)(&postLike.Post.Content),
+				(*NullString)(&postLike.Post.FileURL),
+				&postLike.Post.CommunityID,
+				&postLike.Post.UserID,
+				(*NullTime)(&postLike.Post.CreatedAt),
+				(*NullTime)(&postLike.Post.UpdatedAt),
+			)
+		}
+
+		if err := rows.Scan(append(dest, &n)...); err != nil {
 			return nil, n, err
 		}
 
+		if filter.Fill {
+			postLike.User.ID = postLike.UserID
+			postLike.Post.ID = postLike.PostID
+		}
+
 		postLikes = append(postLikes, &postLike)
 	}
 	if err := rows.Err(); err != nil {
@@ -117,15 +162,26 @@ func findPostLikes(ctx context.Context, tx *Tx, filter sm.PostLikeFilter) (_ []*
 	return postLikes, n, nil
 }
 
-func deletePostLike(ctx context.Context, tx *Tx, id uint) error {
+func deletePostLike(ctx context.Context, tx *Tx, postID, userID uint) error {
+	if sm.UserIDFromContext(ctx) != userID {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to unlike this post.")
+	}
+
 	query := `DELETE FROM "post_likes" WHERE "post_id" = $1 AND "user_id" = $2`
 	args := []interface{}{
-		id,
-		sm.UserIDFromContext(ctx),
+		postID,
+		userID,
 	}
 
-	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
 		return err
+	} else if n == 0 {
+		return sm.Errorf(sm.ENOTFOUND, "Post like not found.")
 	}
 
 	return nil
@@ -117,6 +117,18 @@ func findRelationships(ctx context.Context, tx *Tx, filter sm.RelationshipFilter
 }
 
 func createRelationship(ctx context.Context, tx *Tx, r *sm.Relationship) error {
+	if sm.UserIDFromContext(ctx) != r.FollowerID {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to follow on behalf of this user.")
+	}
+
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := findUserByID(ctx, tx, r.FollowingID); err != nil {
+		return err
+	}
+
 	r.CreatedAt = tx.now
 	r.UpdatedAt = r.CreatedAt
 
@@ -131,13 +143,24 @@ func createRelationship(ctx context.Context, tx *Tx, r *sm.Relationship) error {
 
 	err := tx.QueryRowxContext(ctx, query, args...).Scan(&r.ID)
 	if err != nil {
-		return err
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "relationships_follower_id_following_id_key"`:
+			return sm.Errorf(sm.ECONFLICT, "You are already following this user.")
+		default:
+			return err
+		}
 	}
 
 	return nil
 }
 
 func deleteRelationship(ctx context.Context, tx *Tx, id uint) error {
+	if r, err := findRelationshipByID(ctx, tx, id); err != nil {
+		return err
+	} else if r.FollowerID != sm.UserIDFromContext(ctx) {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to delete this relationship.")
+	}
+
 	query := `DELETE FROM "relationships" WHERE id = $1`
 
 	if _, err := tx.ExecContext(ctx, query, id); err != nil {
@@ -0,0 +1,296 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+var _ sm.ReportService = (*ReportService)(nil)
+
+type ReportService struct {
+	db *DB
+}
+
+func NewReportService(db *DB) *ReportService {
+	return &ReportService{db: db}
+}
+
+func (s *ReportService) FindReportByID(ctx context.Context, id uint) (*sm.Report, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	report, err := findReportByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *ReportService) FindReports(ctx context.Context, filter sm.ReportFilter) ([]*sm.Report, int, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	return findReports(ctx, tx, filter)
+}
+
+func (s *ReportService) FindReportedPosts(ctx context.Context, filter sm.ReportFilter) ([]*sm.ReportedPost, int, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	return findReportedPosts(ctx, tx, filter)
+}
+
+func (s *ReportService) CreateReport(ctx context.Context, report *sm.Report) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := createReport(ctx, tx, report); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *ReportService) ResolveReports(ctx context.Context, postID uint, action string) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := resolveReports(ctx, tx, postID, action); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func findReportByID(ctx context.Context, tx *Tx, id uint) (*sm.Report, error) {
+	a, _, err := findReports(ctx, tx, sm.ReportFilter{ID: &id})
+	if err != nil {
+		return nil, err
+	} else if len(a) == 0 {
+		return nil, &sm.Error{Code: sm.ENOTFOUND, Message: "Report not found."}
+	}
+	return a[0], nil
+}
+
+func findReports(ctx context.Context, tx *Tx, filter sm.ReportFilter) (_ []*sm.Report, n int, err error) {
+	where, args := []string{}, []interface{}{}
+	argPos := 1
+
+	if v := filter.ID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.PostID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"post_id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.CommunityID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"community_id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.ReportedBy; v != nil {
+		where, args = append(where, fmt.Sprintf(`"reported_by" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.Status; v != nil {
+		where, args = append(where, fmt.Sprintf(`"status" = $%d`, argPos)), append(args, *v)
+	}
+
+	query := `SELECT "id", COALESCE("post_id", 0), "community_id", COALESCE("reported_by", 0), "report_reason", "status",
+	"resolution", "resolved_by", "resolved_at", "report_date", COUNT(*) OVER()
+	FROM "reports"` + formatWhereClause(where) + ` ORDER BY "report_date" ASC` + formatLimitOffset(filter.Limit, filter.Offset)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, n, err
+	}
+	defer rows.Close()
+
+	reports := make([]*sm.Report, 0)
+	for rows.Next() {
+		var report sm.Report
+		if err := rows.Scan(
+			&report.ID,
+			&report.PostID,
+			&report.CommunityID,
+			&report.ReportedBy,
+			(*NullString)(&report.Reason),
+			&report.Status,
+			(*NullString)(&report.Resolution),
+			&report.ResolvedBy,
+			&report.ResolvedAt,
+			(*NullTime)(&report.ReportedAt),
+			&n,
+		); err != nil {
+			return nil, n, err
+		}
+
+		reports = append(reports, &report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return reports, n, nil
+}
+
+// findReportedPosts groups the reports matching filter by post, most reported
+// first.
+func findReportedPosts(ctx context.Context, tx *Tx, filter sm.ReportFilter) (_ []*sm.ReportedPost, n int, err error) {
+	where, args := []string{`"post_id" IS NOT NULL`}, []interface{}{}
+	argPos := 1
+
+	if v := filter.CommunityID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"community_id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.Status; v != nil {
+		where, args = append(where, fmt.Sprintf(`"status" = $%d`, argPos)), append(args, *v)
+	}
+
+	query := `SELECT "post_id", COUNT(*), MAX("report_date"), COUNT(*) OVER()
+	FROM "reports"` + formatWhereClause(where) + ` GROUP BY "post_id"
+	ORDER BY COUNT(*) DESC, MAX("report_date") DESC` + formatLimitOffset(filter.Limit, filter.Offset)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, n, err
+	}
+	defer rows.Close()
+
+	postIDs := make([]uint, 0)
+	reportedPosts := make([]*sm.ReportedPost, 0)
+	for rows.Next() {
+		var postID uint
+		var reportedPost sm.ReportedPost
+		if err := rows.Scan(
+			&postID,
+			&reportedPost.ReportCount,
+			(*NullTime)(&reportedPost.LastReportedAt),
+			&n,
+		); err != nil {
+			return nil, n, err
+		}
+
+		postIDs = append(postIDs, postID)
+		reportedPosts = append(reportedPosts, &reportedPost)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	rows.Close()
+
+	for i, postID := range postIDs {
+		if reportedPosts[i].Post, err = findPostByID(ctx, tx, postID); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return reportedPosts, n, nil
+}
+
+func createReport(ctx context.Context, tx *Tx, report *sm.Report) error {
+	if sm.UserIDFromContext(ctx) != report.ReportedBy {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to create this report.")
+	}
+
+	if err := report.Validate(); err != nil {
+		return err
+	}
+
+	post, err := findPostByID(ctx, tx, report.PostID)
+	if err != nil {
+		return err
+	}
+
+	report.CommunityID = post.CommunityID
+	report.Status = sm.ReportStatusOpen
+	report.ReportedAt = tx.now
+
+	query := `INSERT INTO "reports" ("post_id", "community_id", "reported_by", "report_reason", "status", "report_date")
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	args := []interface{}{
+		report.PostID,
+		report.CommunityID,
+		report.ReportedBy,
+		report.Reason,
+		report.Status,
+		(*NullTime)(&report.ReportedAt),
+	}
+
+	if err := tx.QueryRowxContext(ctx, query, args...).Scan(&report.ID); err != nil {
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "reports_post_id_reported_by_key"`:
+			return sm.Errorf(sm.ECONFLICT, "You have already reported this post.")
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveReports closes every open report of the post, recording the action
+// and who took it, then carries the action out.
+func resolveReports(ctx context.Context, tx *Tx, postID uint, action string) error {
+	switch action {
+	case sm.ReportActionDismiss, sm.ReportActionRemovePost, sm.ReportActionBanAuthor:
+	default:
+		return sm.Errorf(sm.EINVALID, "Invalid action: %s.", action)
+	}
+
+	post, err := findPostByID(ctx, tx, postID)
+	if err != nil {
+		return err
+	}
+
+	if ok, err := canModifyCommunity(ctx, tx, post.CommunityID); err != nil {
+		return err
+	} else if !ok {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to resolve reports in this community.")
+	}
+
+	status := sm.ReportStatusOpen
+	if _, n, err := findReports(ctx, tx, sm.ReportFilter{PostID: &post.ID, Status: &status}); err != nil {
+		return err
+	} else if n == 0 {
+		return sm.Errorf(sm.ENOTFOUND, "This post has no open reports.")
+	}
+
+	query := `UPDATE "reports" SET "status" = $1, "resolution" = $2, "resolved_by" = $3, "resolved_at" = $4
+	WHERE "post_id" = $5 AND "status" = $6`
+	args := []interface{}{
+		sm.ReportStatusResolved,
+		action,
+		sm.UserIDFromContext(ctx),
+		(*NullTime)(&tx.now),
+		post.ID,
+		sm.ReportStatusOpen,
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	switch action {
+	case sm.ReportActionRemovePost:
+		return deletePost(ctx, tx, post.ID)
+	case sm.ReportActionBanAuthor:
+		if banned, err := isBannedFromCommunity(ctx, tx, post.CommunityID, post.UserID); err != nil {
+			return err
+		} else if banned {
+			return nil
+		}
+		return createCBU(ctx, tx, &sm.CBU{CommunityID: post.CommunityID, UserID: post.UserID})
+	}
+
+	return nil
+}
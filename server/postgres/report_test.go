@@ -0,0 +1,60 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	sm "github.com/maliByatzes/socialmedia"
+	"github.com/maliByatzes/socialmedia/postgres"
+)
+
+func TestReportService_FindReportedPosts(t *testing.T) {
+	// Resolved reports of a removed post keep a NULL post_id and must not
+	// break the resolved queue.
+	t.Run("RemovedPost", func(t *testing.T) {
+		db := MustOpenDB(t)
+		ctx := context.Background()
+
+		author := MustCreateUser(t, db, "author@example.com")
+		reporter := MustCreateUser(t, db, "reporter@example.com")
+
+		adminCtx := sm.NewContextWithAdmin(ctx, &sm.Admin{ID: 1})
+		com := sm.Community{Name: "golang"}
+		if err := postgres.NewCommunityService(db).CreateCommunity(adminCtx, &com); err != nil {
+			t.Fatal(err)
+		}
+
+		post := sm.Post{Content: "spam", CommunityID: com.ID, UserID: author.ID}
+		if err := postgres.NewPostService(db).CreatePost(sm.NewContextWithUser(ctx, author), &post); err != nil {
+			t.Fatal(err)
+		}
+
+		s := postgres.NewReportService(db)
+		reporterCtx := sm.NewContextWithUser(ctx, reporter)
+		if err := s.CreateReport(reporterCtx, &sm.Report{PostID: post.ID, ReportedBy: reporter.ID, Reason: "Spam"}); err != nil {
+			t.Fatal(err)
+		} else if err := s.ResolveReports(sm.NewContextWithAdmin(reporterCtx, &sm.Admin{ID: 1}), post.ID, sm.ReportActionRemovePost); err != nil {
+			t.Fatal(err)
+		}
+
+		status := sm.ReportStatusResolved
+		if reportedPosts, n, err := s.FindReportedPosts(ctx, sm.ReportFilter{CommunityID: &com.ID, Status: &status}); err != nil {
+			t.Fatal(err)
+		} else if n != 0 || len(reportedPosts) != 0 {
+			t.Fatalf("unexpected reported posts: n=%d %#v", n, reportedPosts)
+		}
+	})
+}
+
+// MustCreateUser creates a user with the given email or fails the test.
+func MustCreateUser(tb testing.TB, db *postgres.DB, email string) *sm.User {
+	tb.Helper()
+
+	user := sm.User{Name: email, Email: email, Role: "general"}
+	if err := user.SetPassword("password"); err != nil {
+		tb.Fatal(err)
+	} else if err := postgres.NewUserService(db).CreateUser(context.Background(), &user); err != nil {
+		tb.Fatal(err)
+	}
+	return &user
+}
@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+var _ sm.RuleService = (*RuleService)(nil)
+
+type RuleService struct {
+	db *DB
+}
+
+func NewRuleService(db *DB) *RuleService {
+	return &RuleService{db: db}
+}
+
+func (s *RuleService) FindRuleByID(ctx context.Context, id uint) (*sm.Rule, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	rule, err := findRuleByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *RuleService) FindRules(ctx context.Context, filter sm.RuleFilter) ([]*sm.Rule, int, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	return findRules(ctx, tx, filter)
+}
+
+func (s *RuleService) CreateRule(ctx context.Context, rule *sm.Rule) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := createRule(ctx, tx, rule); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *RuleService) UpdateRule(ctx context.Context, id uint, upd sm.RuleUpdate) (*sm.Rule, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	rule, err := updateRule(ctx, tx, id, upd)
+	if err != nil {
+		return rule, err
+	} else if err := tx.Commit(); err != nil {
+		return rule, err
+	}
+
+	return rule, nil
+}
+
+func (s *RuleService) DeleteRule(ctx context.Context, id uint) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := deleteRule(ctx, tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func findRuleByID(ctx context.Context, tx *Tx, id uint) (*sm.Rule, error) {
+	a, _, err := findRules(ctx, tx, sm.RuleFilter{ID: &id})
+	if err != nil {
+		return nil, err
+	} else if len(a) == 0 {
+		return nil, &sm.Error{Code: sm.ENOTFOUND, Message: "Rule not found."}
+	}
+	return a[0], nil
+}
+
+func findRules(ctx context.Context, tx *Tx, filter sm.RuleFilter) (_ []*sm.Rule, n int, err error) {
+	where, args := []string{}, []interface{}{}
+	argPos := 1
+
+	if v := filter.ID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.CommunityID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"community_id" = $%d`, argPos)), append(args, *v)
+	}
+
+	query := `SELECT "id", "community_id", "rule", "description", "position", "created_at", "updated_at", COUNT(*) OVER()
+	FROM "rules"` + formatWhereClause(where) + ` ORDER BY "community_id" ASC, "position" ASC` + formatLimitOffset(filter.Limit, filter.Offset)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, n, err
+	}
+	defer rows.Close()
+
+	rules := make([]*sm.Rule, 0)
+	for rows.Next() {
+		var rule sm.Rule
+		if err := rows.Scan(
+			&rule.ID,
+			&rule.CommunityID,
+			&rule.Rule,
+			(*NullString)(&rule.Description),
+			&rule.Position,
+			(*NullTime)(&rule.CreatedAt),
+			(*NullTime)(&rule.UpdatedAt),
+			&n,
+		); err != nil {
+			return nil, n, err
+		}
+
+		rules = append(rules, &rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return rules, n, nil
+}
+
+func createRule(ctx context.Context, tx *Tx, rule *sm.Rule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := findCommunityByID(ctx, tx, rule.CommunityID); err != nil {
+		return err
+	}
+
+	if ok, err := canModifyCommunity(ctx, tx, rule.CommunityID); err != nil {
+		return err
+	} else if !ok {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to add rules to this community.")
+	}
+
+	// New rules are appended after the existing ones.
+	_, n, err := findRules(ctx, tx, sm.RuleFilter{CommunityID: &rule.CommunityID})
+	if err != nil {
+		return err
+	}
+	rule.Position = n + 1
+
+	rule.CreatedAt = tx.now
+	rule.UpdatedAt = rule.CreatedAt
+
+	query := `INSERT INTO "rules" ("community_id", "rule", "description", "position", "created_at", "updated_at")
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	args := []interface{}{
+		rule.CommunityID,
+		rule.Rule,
+		rule.Description,
+		rule.Position,
+		(*NullTime)(&rule.CreatedAt),
+		(*NullTime)(&rule.UpdatedAt),
+	}
+
+	if err := tx.QueryRowxContext(ctx, query, args...).Scan(&rule.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func updateRule(ctx context.Context, tx *Tx, id uint, upd sm.RuleUpdate) (*sm.Rule, error) {
+	rule, err := findRuleByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok, err := canModifyCommunity(ctx, tx, rule.CommunityID); err != nil {
+		return rule, err
+	} else if !ok {
+		return nil, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to update this rule.")
+	}
+
+	if v := upd.Rule; v != nil {
+		rule.Rule = *v
+	}
+
+	if v := upd.Description; v != nil {
+		rule.Description = *v
+	}
+
+	if err := rule.Validate(); err != nil {
+		return rule, err
+	}
+
+	if v := upd.Position; v != nil && *v != rule.Position {
+		_, n, err := findRules(ctx, tx, sm.RuleFilter{CommunityID: &rule.CommunityID})
+		if err != nil {
+			return rule, err
+		}
+
+		position := min(max(*v, 1), n)
+
+		// Shift the rules between the old and new position by one.
+		var query string
+		if position > rule.Position {
+			query = `UPDATE "rules" SET "position" = "position" - 1 WHERE "community_id" = $1 AND "position" > $2 AND "position" <= $3`
+		} else {
+			query = `UPDATE "rules" SET "position" = "position" + 1 WHERE "community_id" = $1 AND "position" < $2 AND "position" >= $3`
+		}
+
+		if _, err := tx.ExecContext(ctx, query, rule.CommunityID, rule.Position, position); err != nil {
+			return rule, err
+		}
+
+		rule.Position = position
+	}
+
+	rule.UpdatedAt = tx.now
+
+	args := []interface{}{
+		rule.Rule,
+		rule.Description,
+		rule.Position,
+		(*NullTime)(&rule.UpdatedAt),
+		rule.ID,
+	}
+	query := `UPDATE "rules" SET "rule" = $1, "description" = $2, "position" = $3, "updated_at" = $4 WHERE "id" = $5`
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return rule, err
+	}
+
+	return rule, nil
+}
+
+func deleteRule(ctx context.Context, tx *Tx, id uint) error {
+	rule, err := findRuleByID(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if ok, err := canModifyCommunity(ctx, tx, rule.CommunityID); err != nil {
+		return err
+	} else if !ok {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to delete this rule.")
+	}
+
+	query := `DELETE FROM "rules" WHERE "id" = $1`
+
+	if _, err := tx.ExecContext(ctx, query, rule.ID); err != nil {
+		return err
+	}
+
+	// Close the gap left by the deleted rule.
+	query = `UPDATE "rules" SET "position" = "position" - 1 WHERE "community_id" = $1 AND "position" > $2`
+
+	if _, err := tx.ExecContext(ctx, query, rule.CommunityID, rule.Position); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	sm "github.com/maliByatzes/socialmedia"
+)
+
+var _ sm.SavedPostService = (*SavedPostService)(nil)
+
+type SavedPostService struct {
+	db *DB
+}
+
+func NewSavedPostService(db *DB) *SavedPostService {
+	return &SavedPostService{db: db}
+}
+
+func (s *SavedPostService) FindSavedPosts(ctx context.Context, filter sm.SavedPostFilter) ([]*sm.SavedPost, int, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	return findSavedPosts(ctx, tx, filter)
+}
+
+func (s *SavedPostService) CreateSavedPost(ctx context.Context, sp *sm.SavedPost) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := createSavedPost(ctx, tx, sp); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *SavedPostService) DeleteSavedPost(ctx context.Context, userID, postID uint) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := deleteSavedPost(ctx, tx, userID, postID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func findSavedPosts(ctx context.Context, tx *Tx, filter sm.SavedPostFilter) (_ []*sm.SavedPost, n int, err error) {
+	where, args := []string{}, []interface{}{}
+	argPos := 1
+
+	if v := filter.UserID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"saved_posts"."user_id" = $%d`, argPos)), append(args, *v)
+		argPos++
+	}
+
+	if v := filter.PostID; v != nil {
+		where, args = append(where, fmt.Sprintf(`"saved_posts"."post_id" = $%d`, argPos)), append(args, *v)
+	}
+
+	// Leave out posts from communities the saving user has since been
+	// banned from.
+	where = append(where, `"posts"."community_id" NOT IN (SELECT "community_id" FROM "community_banned_users"
+		WHERE "community_banned_users"."user_id" = "saved_posts"."user_id")`)
+
+	query := `SELECT "saved_posts"."user_id", "saved_posts"."post_id", "saved_posts"."saved_at",
+	"posts"."content", "posts"."file_url", "posts"."community_id", "posts"."user_id", "posts"."created_at", "posts"."updated_at",
+	COUNT(*) OVER()
+	FROM "saved_posts" JOIN "posts" ON "posts"."id" = "saved_posts"."post_id"` +
+		formatWhereClause(where) + ` ORDER BY "saved_posts"."saved_at" DESC` + formatLimitOffset(filter.Limit, filter.Offset)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, n, err
+	}
+	defer rows.Close()
+
+	sps := make([]*sm.SavedPost, 0)
+	for rows.Next() {
+		sp := sm.SavedPost{Post: &sm.Post{}}
+		if err := rows.Scan(
+			&sp.UserID,
+			&sp.PostID,
+			(*NullTime)(&sp.SavedAt),
+			(*NullString)(&sp.Post.Content),
+			(*NullString)(&sp.Post.FileURL),
+			&sp.Post.CommunityID,
+			&sp.Post.UserID,
+			(*NullTime)(&sp.Post.CreatedAt),
+			(*NullTime)(&sp.Post.UpdatedAt),
+			&n,
+		); err != nil {
+			return nil, n, err
+		}
+		sp.Post.ID = sp.PostID
+
+		sps = append(sps, &sp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return sps, n, nil
+}
+
+func createSavedPost(ctx context.Context, tx *Tx, sp *sm.SavedPost) error {
+	if sm.UserIDFromContext(ctx) != sp.UserID {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to save posts for this user.")
+	}
+
+	if _, err := findPostByID(ctx, tx, sp.PostID); err != nil {
+		return err
+	}
+
+	sp.SavedAt = tx.now
+
+	query := `INSERT INTO "saved_posts" ("user_id", "post_id", "saved_at")
+	VALUES ($1, $2, $3)`
+	args := []interface{}{
+		sp.UserID,
+		sp.PostID,
+		(*NullTime)(&sp.SavedAt),
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "saved_posts_pkey"`:
+			return sm.Errorf(sm.ECONFLICT, "You have already saved this post.")
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deleteSavedPost(ctx context.Context, tx *Tx, userID, postID uint) error {
+	if sm.UserIDFromContext(ctx) != userID {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to unsave posts for this user.")
+	}
+
+	query := `DELETE FROM "saved_posts" WHERE "user_id" = $1 AND "post_id" = $2`
+
+	result, err := tx.ExecContext(ctx, query, userID, postID)
+	if err != nil {
+		return err
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sm.Errorf(sm.ENOTFOUND, "Saved post not found.")
+	}
+
+	return nil
+}
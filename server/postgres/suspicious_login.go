@@ -7,6 +7,8 @@ import (
 	sm "github.com/maliByatzes/socialmedia"
 )
 
+var _ sm.SuspiciousLoginService = (*SuspiciousLoginService)(nil)
+
 type SuspiciousLoginService struct {
 	db *DB
 }
@@ -58,11 +60,28 @@ func (s *SuspiciousLoginService) CreateSL(ctx context.Context, sl *sm.Suspicious
 }
 
 func (s *SuspiciousLoginService) UpdateSL(ctx context.Context, id uint, upd sm.SLUpdate) (*sm.SuspiciousLogin, error) {
-	return nil, nil
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	sl, err := updateSL(ctx, tx, id, upd)
+	if err != nil {
+		return sl, err
+	} else if err := tx.Commit(); err != nil {
+		return sl, err
+	}
+
+	return sl, nil
 }
 
 func (s *SuspiciousLoginService) DeleteSL(ctx context.Context, id uint) error {
-	return nil
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := deleteSL(ctx, tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func createSL(ctx context.Context, tx *Tx, sl *sm.SuspiciousLogin) error {
@@ -234,3 +253,60 @@ func findSLs(ctx context.Context, tx *Tx, filter sm.SLFilter) (_ []*sm.Suspiciou
 
 	return sls, n, nil
 }
+
+// updateSL changes the verification state of a suspicious login. Only the
+// user it belongs to may update it.
+func updateSL(ctx context.Context, tx *Tx, id uint, upd sm.SLUpdate) (*sm.SuspiciousLogin, error) {
+	sl, err := findSLByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	} else if sl.UserID != sm.UserIDFromContext(ctx) {
+		return nil, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to update this login.")
+	}
+
+	if v := upd.UnverifiedAttempts; v != nil {
+		sl.UnverifiedAttempts = *v
+	}
+
+	if v := upd.IsTrusted; v != nil {
+		sl.IsTrusted = *v
+	}
+
+	if v := upd.IsBlocked; v != nil {
+		sl.IsBlocked = *v
+	}
+
+	sl.UpdatedAt = tx.now
+
+	args := []interface{}{
+		sl.UnverifiedAttempts,
+		sl.IsTrusted,
+		sl.IsBlocked,
+		(*NullTime)(&sl.UpdatedAt),
+		sl.ID,
+	}
+	query := `UPDATE "suspicious_logins" SET "unverified_attempts" = $1, "is_trusted" = $2, "is_blocked" = $3, "updated_at" = $4 WHERE "id" = $5`
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return sl, err
+	}
+
+	return sl, nil
+}
+
+func deleteSL(ctx context.Context, tx *Tx, id uint) error {
+	sl, err := findSLByID(ctx, tx, id)
+	if err != nil {
+		return err
+	} else if sl.UserID != sm.UserIDFromContext(ctx) {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to delete this login.")
+	}
+
+	query := `DELETE FROM "suspicious_logins" WHERE "id" = $1`
+
+	if _, err := tx.ExecContext(ctx, query, sl.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
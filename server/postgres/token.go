@@ -2,11 +2,14 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	sm "github.com/maliByatzes/socialmedia"
 )
 
+var _ sm.TokenService = (*TokenService)(nil)
+
 type TokenService struct {
 	db *DB
 }
@@ -44,8 +47,63 @@ func (s *TokenService) CreateToken(ctx context.Context, token *sm.Token) error {
 
 	return tx.Commit()
 }
+
 func (s *TokenService) UpdateToken(ctx context.Context, id uint, upd sm.TokenUpdate) (*sm.Token, error) {
-	return nil, nil
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	token, err := updateToken(ctx, tx, id, upd)
+	if err != nil {
+		return token, err
+	} else if err := tx.Commit(); err != nil {
+		return token, err
+	}
+
+	return token, nil
+}
+
+func (s *TokenService) DeleteOtherTokens(ctx context.Context, id uint) (int, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	n, err := deleteOtherTokens(ctx, tx, id)
+	if err != nil {
+		return 0, err
+	} else if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (s *TokenService) DeleteUserTokens(ctx context.Context, userID uint) (int, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	n, err := deleteUserTokens(ctx, tx, userID)
+	if err != nil {
+		return 0, err
+	} else if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (s *TokenService) RotateToken(ctx context.Context, refreshToken string, upd sm.TokenUpdate) (*sm.Token, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	// A reused token revokes the user's tokens, which has to be committed
+	// even though the rotation fails.
+	token, err := rotateToken(ctx, tx, refreshToken, upd)
+	if err != nil && sm.ErrorCode(err) != sm.ENOTAUTHORIZED {
+		return nil, err
+	} else if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return token, err
 }
 
 func (s *TokenService) DeleteToken(ctx context.Context, id uint) error {
@@ -92,7 +150,7 @@ func findTokens(ctx context.Context, tx *Tx, filter sm.TokenFilter) (_ []*sm.Tok
 		where, args = append(where, fmt.Sprintf(`"refresh_token" = $%d`, argPos)), append(args, *v)
 	}
 
-	query := `SELECT "id", "user_id", "refresh_token", "access_token", "created_at", "updated_at", COUNT(*) OVER()
+	query := `SELECT "id", "user_id", "refresh_token", "access_token", "context_id", "last_used_at", "created_at", "updated_at", COUNT(*) OVER()
 	FROM "tokens"` + formatWhereClause(where) + ` ORDER BY id ASC` + formatLimitOffset(filter.Limit, filter.Offset)
 
 	rows, err := tx.QueryContext(ctx, query, args...)
@@ -109,6 +167,8 @@ func findTokens(ctx context.Context, tx *Tx, filter sm.TokenFilter) (_ []*sm.Tok
 			&token.UserID,
 			(*NullString)(&token.RefreshToken),
 			(*NullString)(&token.AccessToken),
+			&token.ContextID,
+			(*NullTime)(&token.LastUsedAt),
 			(*NullTime)(&token.CreatedAt),
 			(*NullTime)(&token.UpdatedAt),
 			&n,
@@ -128,13 +188,16 @@ func findTokens(ctx context.Context, tx *Tx, filter sm.TokenFilter) (_ []*sm.Tok
 func createToken(ctx context.Context, tx *Tx, token *sm.Token) error {
 	token.CreatedAt = tx.now
 	token.UpdatedAt = token.CreatedAt
+	token.LastUsedAt = token.CreatedAt
 
-	query := `INSERT INTO "tokens" ("user_id", "refresh_token", "access_token", "created_at", "updated_at") 
-	VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	query := `INSERT INTO "tokens" ("user_id", "refresh_token", "access_token", "context_id", "last_used_at", "created_at", "updated_at") 
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
 	args := []interface{}{
 		token.UserID,
 		token.RefreshToken,
 		token.AccessToken,
+		token.ContextID,
+		(*NullTime)(&token.LastUsedAt),
 		(*NullTime)(&token.CreatedAt),
 		(*NullTime)(&token.UpdatedAt),
 	}
@@ -161,3 +224,152 @@ func deleteToken(ctx context.Context, tx *Tx, id uint) error {
 
 	return nil
 }
+
+func updateToken(ctx context.Context, tx *Tx, id uint, upd sm.TokenUpdate) (*sm.Token, error) {
+	token, err := findTokenByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	} else if token.UserID != sm.UserIDFromContext(ctx) {
+		return nil, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to update this token.")
+	}
+
+	if v := upd.AccessToken; v != nil {
+		token.AccessToken = *v
+	}
+
+	if v := upd.RefreshToken; v != nil {
+		token.RefreshToken = *v
+	}
+
+	if v := upd.LastUsedAt; v != nil {
+		token.LastUsedAt = *v
+	}
+
+	token.UpdatedAt = tx.now
+
+	args := []interface{}{
+		token.RefreshToken,
+		token.AccessToken,
+		(*NullTime)(&token.LastUsedAt),
+		(*NullTime)(&token.UpdatedAt),
+		token.ID,
+	}
+	query := `UPDATE "tokens" SET "refresh_token" = $1, "access_token" = $2, "last_used_at" = $3, "updated_at" = $4 WHERE "id" = $5`
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return token, err
+	}
+
+	return token, nil
+}
+
+// rotateToken swaps the refresh token in a single conditional update, so two
+// requests presenting the same token cannot both rotate it. The old refresh
+// token is kept in rotated_tokens to detect its reuse.
+func rotateToken(ctx context.Context, tx *Tx, refreshToken string, upd sm.TokenUpdate) (*sm.Token, error) {
+	if upd.AccessToken == nil || upd.RefreshToken == nil {
+		return nil, sm.Errorf(sm.EINVALID, "Access and refresh tokens are required.")
+	}
+
+	token := sm.Token{
+		UserID:       sm.UserIDFromContext(ctx),
+		AccessToken:  *upd.AccessToken,
+		RefreshToken: *upd.RefreshToken,
+		UpdatedAt:    tx.now,
+	}
+
+	token.LastUsedAt = tx.now
+
+	query := `UPDATE "tokens" SET "refresh_token" = $1, "access_token" = $2, "updated_at" = $3, "last_used_at" = $3
+	WHERE "refresh_token" = $4 AND "user_id" = $5 RETURNING "id", "context_id", "created_at"`
+	args := []interface{}{
+		token.RefreshToken,
+		token.AccessToken,
+		(*NullTime)(&token.UpdatedAt),
+		refreshToken,
+		token.UserID,
+	}
+
+	err := tx.QueryRowxContext(ctx, query, args...).Scan(&token.ID, &token.ContextID, (*NullTime)(&token.CreatedAt))
+	if err == sql.ErrNoRows {
+		return nil, revokeReusedToken(ctx, tx, refreshToken)
+	} else if err != nil {
+		return nil, err
+	}
+
+	query = `INSERT INTO "rotated_tokens" ("token_id", "user_id", "refresh_token", "rotated_at") VALUES ($1, $2, $3, $4)`
+
+	if _, err := tx.ExecContext(ctx, query, token.ID, token.UserID, refreshToken, (*NullTime)(&tx.now)); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// revokeReusedToken is called with a refresh token that is not current. If it
+// was rotated before, someone is replaying it, so every token of its user is
+// deleted.
+func revokeReusedToken(ctx context.Context, tx *Tx, refreshToken string) error {
+	var userID uint
+
+	query := `SELECT "user_id" FROM "rotated_tokens" WHERE "refresh_token" = $1`
+
+	if err := tx.QueryRowxContext(ctx, query, refreshToken).Scan(&userID); err == sql.ErrNoRows {
+		return sm.Errorf(sm.ENOTFOUND, "Token is not found.")
+	} else if err != nil {
+		return err
+	}
+
+	query = `DELETE FROM "tokens" WHERE "user_id" = $1`
+
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		return err
+	}
+
+	return sm.Errorf(sm.ENOTAUTHORIZED, "Refresh token has already been used.")
+}
+
+func deleteOtherTokens(ctx context.Context, tx *Tx, id uint) (int, error) {
+	userID := sm.UserIDFromContext(ctx)
+	if userID == 0 {
+		return 0, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to delete these tokens.")
+	}
+
+	query := `DELETE FROM "tokens" WHERE "user_id" = $1 AND "id" <> $2`
+
+	result, err := tx.ExecContext(ctx, query, userID, id)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(n), nil
+}
+
+func deleteUserTokens(ctx context.Context, tx *Tx, userID uint) (int, error) {
+	if sm.AdminFromContext(ctx) == nil {
+		return 0, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to delete these tokens.")
+	}
+
+	if _, err := findUserByID(ctx, tx, userID); err != nil {
+		return 0, err
+	}
+
+	query := `DELETE FROM "tokens" WHERE "user_id" = $1`
+
+	result, err := tx.ExecContext(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(n), nil
+}
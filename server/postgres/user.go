@@ -65,11 +65,55 @@ func (s *UserService) FindUsers(ctx context.Context, filter sm.UserFilter) ([]*s
 }
 
 func (s *UserService) UpdateUser(ctx context.Context, id uint, up sm.UserUpdate) (*sm.User, error) {
-	return nil, sm.Errorf(sm.ENOTIMPLEMENTED, "")
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	user, err := updateUser(ctx, tx, id, up)
+	if err != nil {
+		return user, err
+	} else if err := tx.Commit(); err != nil {
+		return user, err
+	}
+
+	return user, nil
 }
 
 func (s *UserService) DeleteUser(ctx context.Context, id uint) error {
-	return sm.Errorf(sm.ENOTIMPLEMENTED, "")
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := deleteUser(ctx, tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *UserService) VerifyUserEmail(ctx context.Context, email, code string) (*sm.User, error) {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	user, err := verifyUserEmail(ctx, tx, email, code)
+	if err != nil && sm.ErrorCode(err) != sm.EINVALID {
+		return nil, err
+	} else if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return user, err
+}
+
+func (s *UserService) ChangePassword(ctx context.Context, id uint, currentPassword, newPassword string, keepTokenID uint) error {
+	tx := s.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	if err := changePassword(ctx, tx, id, currentPassword, newPassword); err != nil {
+		return err
+	} else if _, err := deleteOtherTokens(ctx, tx, keepTokenID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func createUser(ctx context.Context, tx *Tx, user *sm.User) error {
@@ -146,6 +190,16 @@ func findUsers(ctx context.Context, tx *Tx, filter sm.UserFilter) (_ []*sm.User,
 		where, args = append(where, fmt.Sprintf(`"email" = $%d`, argPosition)), append(args, *v)
 	}
 
+	if v := filter.FollowersOf; v != nil {
+		argPosition++
+		where, args = append(where, fmt.Sprintf(`"id" IN (SELECT "follower_id" FROM "relationships" WHERE "following_id" = $%d)`, argPosition)), append(args, *v)
+	}
+
+	if v := filter.FollowedBy; v != nil {
+		argPosition++
+		where, args = append(where, fmt.Sprintf(`"id" IN (SELECT "following_id" FROM "relationships" WHERE "follower_id" = $%d)`, argPosition)), append(args, *v)
+	}
+
 	query := `SELECT "id", "name", "email", "password", "avatar", "location",
   "bio", "interests", "role", "is_email_verified", "created_at", "updated_at",
   COUNT(*) OVER() FROM "users"` + formatWhereClause(where) + ` ORDER BY id
@@ -187,12 +241,155 @@ func findUsers(ctx context.Context, tx *Tx, filter sm.UserFilter) (_ []*sm.User,
 	return users, n, nil
 }
 
+// updateUser applies upd to the user. Users may only update themselves, and
+// only admins may change a role or mark an email as verified. Changing the
+// email marks it as unverified again.
 func updateUser(ctx context.Context, tx *Tx, id uint, upd sm.UserUpdate) (*sm.User, error) {
-	panic("Not implemented")
+	user, err := findUserByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	isAdmin := sm.AdminFromContext(ctx) != nil
+	if !isAdmin && user.ID != sm.UserIDFromContext(ctx) {
+		return nil, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to update this user.")
+	}
+
+	if v := upd.Role; v != nil && *v != user.Role {
+		if !isAdmin {
+			return nil, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to change the role.")
+		}
+		user.Role = *v
+	}
+
+	if v := upd.IsEmailVerified; v != nil && *v != user.IsEmailVerified {
+		if !isAdmin {
+			return nil, sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to change the email verification.")
+		}
+		user.IsEmailVerified = *v
+	}
+
+	if v := upd.Email; v != nil && *v != user.Email {
+		user.Email = *v
+		user.IsEmailVerified = false
+	}
+
+	if v := upd.Name; v != nil {
+		user.Name = *v
+	}
+
+	if v := upd.Avatar; v != nil {
+		user.Avatar = *v
+	}
+
+	if v := upd.Location; v != nil {
+		user.Location = *v
+	}
+
+	if v := upd.Bio; v != nil {
+		user.Bio = *v
+	}
+
+	if v := upd.Interests; v != nil {
+		user.Interests = *v
+	}
+
+	if err := user.Validate(); err != nil {
+		return user, err
+	}
+
+	user.UpdatedAt = tx.now
+
+	args := []interface{}{
+		user.Name,
+		user.Email,
+		user.Avatar,
+		user.Location,
+		user.Bio,
+		user.Interests,
+		user.Role,
+		user.IsEmailVerified,
+		(*NullTime)(&user.UpdatedAt),
+		user.ID,
+	}
+	query := `UPDATE "users" SET "name" = $1, "email" = $2, "avatar" = $3, "location" = $4, "bio" = $5,
+	"interests" = $6, "role" = $7, "is_email_verified" = $8, "updated_at" = $9 WHERE "id" = $10`
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "users_email_key"`:
+			return user, sm.Errorf(sm.ECONFLICT, "this email is already exists.")
+		default:
+			return user, err
+		}
+	}
+
+	return user, nil
 }
 
+// deleteUser deletes the user with everything they own. Reports they filed
+// or resolved are kept for the moderation record, without their id.
 func deleteUser(ctx context.Context, tx *Tx, id uint) error {
-	panic("Not implemented")
+	user, err := findUserByID(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if sm.AdminFromContext(ctx) == nil && user.ID != sm.UserIDFromContext(ctx) {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to delete this user.")
+	}
+
+	// Rows referencing the user's posts are restricted by foreign keys, so
+	// they go before the posts, as in deletePost.
+	for _, table := range []string{"post_likes", "saved_posts", "comments"} {
+		query := fmt.Sprintf(`DELETE FROM "%s" WHERE "post_id" IN (SELECT "id" FROM "posts" WHERE "user_id" = $1)`, table)
+		if _, err := tx.ExecContext(ctx, query, user.ID); err != nil {
+			return err
+		}
+	}
+
+	query := `DELETE FROM "reports" WHERE "status" = $1 AND "post_id" IN (SELECT "id" FROM "posts" WHERE "user_id" = $2)`
+
+	if _, err := tx.ExecContext(ctx, query, sm.ReportStatusOpen, user.ID); err != nil {
+		return err
+	}
+
+	queries := []string{
+		`DELETE FROM "posts" WHERE "user_id" = $1`,
+		`DELETE FROM "post_likes" WHERE "user_id" = $1`,
+		`DELETE FROM "saved_posts" WHERE "user_id" = $1`,
+		`DELETE FROM "comments" WHERE "user_id" = $1`,
+		`DELETE FROM "relationships" WHERE "follower_id" = $1 OR "following_id" = $1`,
+		`DELETE FROM "community_members" WHERE "user_id" = $1`,
+		`DELETE FROM "community_banned_users" WHERE "user_id" = $1`,
+		`DELETE FROM "pending_posts" WHERE "user_id" = $1`,
+		`UPDATE "reports" SET "reported_by" = NULL WHERE "reported_by" = $1`,
+		`UPDATE "reports" SET "resolved_by" = NULL WHERE "resolved_by" = $1`,
+		`DELETE FROM "tokens" WHERE "user_id" = $1`,
+		`DELETE FROM "rotated_tokens" WHERE "user_id" = $1`,
+		`DELETE FROM "context" WHERE "user_id" = $1`,
+		`DELETE FROM "suspicious_logins" WHERE "user_id" = $1`,
+		`DELETE FROM "preferences" WHERE "user_id" = $1`,
+	}
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, user.ID); err != nil {
+			return err
+		}
+	}
+
+	query = `DELETE FROM "emails" WHERE "email" = $1`
+
+	if _, err := tx.ExecContext(ctx, query, user.Email); err != nil {
+		return err
+	}
+
+	query = `DELETE FROM "users" WHERE "id" = $1`
+
+	if _, err := tx.ExecContext(ctx, query, user.ID); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func formatWhereClause(where []string) string {
@@ -204,11 +401,62 @@ func formatWhereClause(where []string) string {
 
 func formatLimitOffset(limit, offset int) string {
 	if limit > 0 && offset > 0 {
-		return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+		return fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
 	} else if limit > 0 {
-		return fmt.Sprintf("LIMIT %d", limit)
+		return fmt.Sprintf(" LIMIT %d", limit)
 	} else if offset > 0 {
-		return fmt.Sprintf("OFFSET %d", offset)
+		return fmt.Sprintf(" OFFSET %d", offset)
 	}
 	return ""
 }
+
+// verifyUserEmail marks the email of the user as verified once the code is
+// consumed. An expired code is still consumed so it cannot be retried.
+func verifyUserEmail(ctx context.Context, tx *Tx, email, code string) (*sm.User, error) {
+	if _, err := consumeEmailVerification(ctx, tx, email, code, sm.EmailForSignup); err != nil {
+		return nil, err
+	}
+
+	user, err := findUserByEmail(ctx, tx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	user.IsEmailVerified = true
+	user.UpdatedAt = tx.now
+
+	query := `UPDATE "users" SET "is_email_verified" = $1, "updated_at" = $2 WHERE "id" = $3`
+
+	if _, err := tx.ExecContext(ctx, query, user.IsEmailVerified, (*NullTime)(&user.UpdatedAt), user.ID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func changePassword(ctx context.Context, tx *Tx, id uint, currentPassword, newPassword string) error {
+	user, err := findUserByID(ctx, tx, id)
+	if err != nil {
+		return err
+	} else if user.ID != sm.UserIDFromContext(ctx) {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "You are not allowed to change this password.")
+	}
+
+	if err := user.VerifyPassword(currentPassword); err != nil {
+		return sm.Errorf(sm.ENOTAUTHORIZED, "Invalid credentials")
+	}
+
+	if err := user.SetPassword(newPassword); err != nil {
+		return err
+	}
+
+	user.UpdatedAt = tx.now
+
+	query := `UPDATE "users" SET "password" = $1, "updated_at" = $2 WHERE "id" = $3`
+
+	if _, err := tx.ExecContext(ctx, query, user.Password, (*NullTime)(&user.UpdatedAt), user.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
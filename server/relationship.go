@@ -13,6 +13,22 @@ type Relationship struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+func (r *Relationship) Validate() error {
+	if r.FollowerID == 0 {
+		return Errorf(EINVALID, "FollowerID is required.")
+	}
+
+	if r.FollowingID == 0 {
+		return Errorf(EINVALID, "FollowingID is required.")
+	}
+
+	if r.FollowerID == r.FollowingID {
+		return Errorf(EINVALID, "You cannot follow yourself.")
+	}
+
+	return nil
+}
+
 type RelationshipService interface {
 	FindRelationshipByID(ctx context.Context, id uint) (*Relationship, error)
 	FindRelationships(ctx context.Context, filter RelationshipFilter) ([]*Relationship, int, error)
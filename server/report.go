@@ -0,0 +1,74 @@
+package socialmedia
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ReportStatusOpen     = "open"
+	ReportStatusResolved = "resolved"
+)
+
+// Actions a moderator can take when resolving the reports of a post.
+const (
+	ReportActionDismiss    = "dismiss"
+	ReportActionRemovePost = "remove_post"
+	ReportActionBanAuthor  = "ban_author"
+)
+
+type Report struct {
+	ID          uint       `json:"id"`
+	PostID      uint       `json:"post_id"`
+	CommunityID uint       `json:"community_id"`
+	ReportedBy  uint       `json:"reported_by"`
+	Reason      string     `json:"reason"`
+	Status      string     `json:"status"`
+	Resolution  string     `json:"resolution,omitempty"`
+	ResolvedBy  *uint      `json:"resolved_by,omitempty"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	ReportedAt  time.Time  `json:"reported_at"`
+}
+
+func (r *Report) Validate() error {
+	if r.PostID == 0 {
+		return Errorf(EINVALID, "PostID is required.")
+	}
+
+	if r.ReportedBy == 0 {
+		return Errorf(EINVALID, "ReportedBy is required.")
+	}
+
+	if r.Reason == "" {
+		return Errorf(EINVALID, "Reason is required.")
+	}
+
+	return nil
+}
+
+// ReportedPost is an entry of a community's report queue, grouping the
+// reports made against a single post.
+type ReportedPost struct {
+	Post           *Post     `json:"post"`
+	ReportCount    int       `json:"report_count"`
+	LastReportedAt time.Time `json:"last_reported_at"`
+}
+
+type ReportService interface {
+	FindReportByID(ctx context.Context, id uint) (*Report, error)
+	FindReports(ctx context.Context, filter ReportFilter) ([]*Report, int, error)
+	FindReportedPosts(ctx context.Context, filter ReportFilter) ([]*ReportedPost, int, error)
+	CreateReport(ctx context.Context, report *Report) error
+	ResolveReports(ctx context.Context, postID uint, action string) error
+}
+
+type ReportFilter struct {
+	ID          *uint   `json:"id"`
+	PostID      *uint   `json:"post_id"`
+	CommunityID *uint   `json:"community_id"`
+	ReportedBy  *uint   `json:"reported_by"`
+	Status      *string `json:"status"`
+
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
@@ -0,0 +1,52 @@
+package socialmedia
+
+import (
+	"context"
+	"time"
+)
+
+type Rule struct {
+	ID          uint      `json:"id"`
+	CommunityID uint      `json:"community_id"`
+	Rule        string    `json:"rule"`
+	Description string    `json:"description"`
+	Position    int       `json:"position"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (r *Rule) Validate() error {
+	if r.CommunityID == 0 {
+		return Errorf(EINVALID, "CommunityID is required.")
+	}
+
+	if r.Rule == "" {
+		return Errorf(EINVALID, "Rule is required.")
+	}
+
+	return nil
+}
+
+type RuleService interface {
+	FindRuleByID(ctx context.Context, id uint) (*Rule, error)
+	FindRules(ctx context.Context, filter RuleFilter) ([]*Rule, int, error)
+	CreateRule(ctx context.Context, rule *Rule) error
+	UpdateRule(ctx context.Context, id uint, upd RuleUpdate) (*Rule, error)
+	DeleteRule(ctx context.Context, id uint) error
+}
+
+type RuleFilter struct {
+	ID          *uint `json:"id"`
+	CommunityID *uint `json:"community_id"`
+
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// RuleUpdate moves the rule when Position is set, renumbering the other
+// rules of the community to keep positions contiguous.
+type RuleUpdate struct {
+	Rule        *string `json:"rule"`
+	Description *string `json:"description"`
+	Position    *int    `json:"position"`
+}
@@ -0,0 +1,28 @@
+package socialmedia
+
+import (
+	"context"
+	"time"
+)
+
+type SavedPost struct {
+	UserID  uint      `json:"user_id"`
+	PostID  uint      `json:"post_id"`
+	SavedAt time.Time `json:"saved_at"`
+
+	Post *Post `json:"post,omitempty"`
+}
+
+type SavedPostService interface {
+	FindSavedPosts(ctx context.Context, filter SavedPostFilter) ([]*SavedPost, int, error)
+	CreateSavedPost(ctx context.Context, sp *SavedPost) error
+	DeleteSavedPost(ctx context.Context, userID, postID uint) error
+}
+
+type SavedPostFilter struct {
+	UserID *uint `json:"user_id"`
+	PostID *uint `json:"post_id"`
+
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
@@ -6,12 +6,31 @@ import (
 )
 
 type Token struct {
-	ID           uint      `json:"id"`
-	UserID       uint      `json:"user_id"`
-	RefreshToken string    `json:"refresh_token"`
-	AccessToken  string    `json:"access_token"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           uint   `json:"id"`
+	UserID       uint   `json:"user_id"`
+	RefreshToken string `json:"refresh_token"`
+	AccessToken  string `json:"access_token"`
+
+	// ContextID is the device the token was issued to, if it is known.
+	ContextID  *uint     `json:"context_id"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Session is a signed in device of a user, described by its token and the
+// context it was issued to.
+type Session struct {
+	ID         uint      `json:"id"`
+	IP         string    `json:"ip"`
+	Country    string    `json:"country"`
+	City       string    `json:"city"`
+	Browser    string    `json:"browser"`
+	OS         string    `json:"os"`
+	Device     string    `json:"device"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
 }
 
 type TokenService interface {
@@ -20,6 +39,20 @@ type TokenService interface {
 	CreateToken(ctx context.Context, token *Token) error
 	UpdateToken(ctx context.Context, id uint, upd TokenUpdate) (*Token, error)
 	DeleteToken(ctx context.Context, id uint) error
+
+	// RotateToken replaces refreshToken, and the access token issued with
+	// it, with the tokens in upd. Presenting a refresh token that was
+	// already rotated revokes every token of its user and returns
+	// ENOTAUTHORIZED.
+	RotateToken(ctx context.Context, refreshToken string, upd TokenUpdate) (*Token, error)
+
+	// DeleteOtherTokens deletes every token of the user in ctx except the
+	// one with id, and returns how many were deleted.
+	DeleteOtherTokens(ctx context.Context, id uint) (int, error)
+
+	// DeleteUserTokens deletes every token of the user, signing them out
+	// everywhere. Only admins may call it.
+	DeleteUserTokens(ctx context.Context, userID uint) (int, error)
 }
 
 type TokenFilter struct {
@@ -33,6 +66,7 @@ type TokenFilter struct {
 }
 
 type TokenUpdate struct {
-	AccessToken  *string `json:"access_token"`
-	RefreshToken *string `json:"refresh_token"`
+	AccessToken  *string    `json:"access_token"`
+	RefreshToken *string    `json:"refresh_token"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
 }
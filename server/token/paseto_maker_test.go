@@ -0,0 +1,76 @@
+package token
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecretKey = "0123456789abcdef0123456789abcdef"
+
+func TestPasetoMaker(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		maker, err := NewPasetoMaker(testSecretKey, nil)
+		require.NoError(t, err)
+
+		tk, payload, err := maker.CreateToken(7, "john", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(tk, pasetoHeader))
+
+		got, err := maker.VerifyToken(tk)
+		require.NoError(t, err)
+		assert.Equal(t, payload.ID, got.ID)
+		assert.Equal(t, payload.Name, got.Name)
+		assert.WithinDuration(t, payload.ExpiresAt, got.ExpiresAt, time.Second)
+	})
+
+	t.Run("ErrKeySize", func(t *testing.T) {
+		_, err := NewPasetoMaker("short", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("ErrExpired", func(t *testing.T) {
+		maker, err := NewPasetoMaker(testSecretKey, nil)
+		require.NoError(t, err)
+
+		tk, _, err := maker.CreateToken(7, "john", -time.Minute)
+		require.NoError(t, err)
+
+		_, err = maker.VerifyToken(tk)
+		assert.Equal(t, ErrExpiredToken, err)
+	})
+
+	t.Run("ErrInvalid", func(t *testing.T) {
+		maker, err := NewPasetoMaker(testSecretKey, nil)
+		require.NoError(t, err)
+		other, err := NewPasetoMaker(strings.Repeat("x", 32), nil)
+		require.NoError(t, err)
+
+		tk, _, err := other.CreateToken(7, "john", time.Minute)
+		require.NoError(t, err)
+
+		_, err = maker.VerifyToken(tk)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("JWTFallback", func(t *testing.T) {
+		jwtMaker, err := NewJWTMaker(testSecretKey)
+		require.NoError(t, err)
+		tk, _, err := jwtMaker.CreateToken(7, "john", time.Minute)
+		require.NoError(t, err)
+
+		maker, err := NewPasetoMaker(testSecretKey, jwtMaker)
+		require.NoError(t, err)
+		payload, err := maker.VerifyToken(tk)
+		require.NoError(t, err)
+		assert.Equal(t, uint(7), payload.ID)
+
+		maker, err = NewPasetoMaker(testSecretKey, nil)
+		require.NoError(t, err)
+		_, err = maker.VerifyToken(tk)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+}
@@ -60,6 +60,15 @@ type UserService interface {
 	FindUsers(ctx context.Context, filter UserFilter) ([]*User, int, error)
 	UpdateUser(ctx context.Context, id uint, up UserUpdate) (*User, error)
 	DeleteUser(ctx context.Context, id uint) error
+
+	// VerifyUserEmail consumes the signup verification code sent to email
+	// and marks the user's email as verified.
+	VerifyUserEmail(ctx context.Context, email, code string) (*User, error)
+
+	// ChangePassword replaces the user's password once currentPassword is
+	// verified and, in the same transaction, deletes every token of the user
+	// except keepTokenID.
+	ChangePassword(ctx context.Context, id uint, currentPassword, newPassword string, keepTokenID uint) error
 }
 
 type UserFilter struct {
@@ -67,6 +76,10 @@ type UserFilter struct {
 	Name  *string `json:"name"`
 	Email *string `json:"email"`
 
+	// Restrict to users following, or followed by, the given user.
+	FollowersOf *uint `json:"followers_of"`
+	FollowedBy  *uint `json:"followed_by"`
+
 	Offset int `json:"offset"`
 	Limit  int `json:"limit"`
 }
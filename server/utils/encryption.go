@@ -2,46 +2,61 @@ package utils
 
 import (
 	"crypto/aes"
-	"log"
-	"os"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
 )
 
-func EncryptData(data []byte) string {
-	key, ok := os.LookupEnv("CRYTO_KEY")
-	if !ok {
-		panic("CRYPTO_KEY is not set!")
-	}
-
+// newGCM builds an AES-GCM cipher from key, which must be 16, 24 or 32
+// bytes long.
+func newGCM(key string) (cipher.AEAD, error) {
 	block, err := aes.NewCipher([]byte(key))
 	if err != nil {
-		// NOTE: panic here for some reason
-		log.Println("%w", err)
-		panic("failed to create new cipher")
+		return nil, fmt.Errorf("creating cipher: %w", err)
 	}
 
-	var encryptedData []byte
+	return cipher.NewGCM(block)
+}
+
+// EncryptData encrypts data with AES-GCM under key and returns the nonce and cipher
+// text base64 encoded, so it can be stored in a text column.
+func EncryptData(key string, data []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
 
-	block.Encrypt(encryptedData, data)
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
 
-	return string(encryptedData)
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, data, nil)), nil
 }
 
-func DecryptData(data []byte) string {
-	key, ok := os.LookupEnv("CRYTO_KEY")
-	if !ok {
-		panic("CRYPTO_KEY is not set!")
+// DecryptData reverses EncryptData.
+func DecryptData(key, data string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
 	}
 
-	block, err := aes.NewCipher([]byte(key))
+	raw, err := base64.StdEncoding.DecodeString(data)
 	if err != nil {
-		// NOTE: panic here for some reason
-		log.Println("%w", err)
-		panic("failed to create new cipher")
+		return "", fmt.Errorf("decoding data: %w", err)
 	}
 
-	var decryptedData []byte
+	if len(raw) < gcm.NonceSize() {
+		return "", errors.New("encrypted data is too short")
+	}
 
-	block.Decrypt(decryptedData, data)
+	nonce, cipherText := raw[:gcm.NonceSize()], raw[gcm.NonceSize():]
+	plainText, err := gcm.Open(nil, nonce, cipherText, nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypting data: %w", err)
+	}
 
-	return string(decryptedData)
+	return string(plainText), nil
 }
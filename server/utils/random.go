@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// RandomCode returns n random bytes from crypto/rand, base64url encoded so
+// the code can be put in a link as is.
+func RandomCode(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating random code: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}